@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"advent_of_code/day3"
+	"advent_of_code/input"
+)
+
+// banksCommand renders the day 3 banks with their max joltage selections highlighted.
+func banksCommand(args []string) error {
+	fs := flag.NewFlagSet("banks", flag.ExitOnError)
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	var cellCounts []int
+	fs.Func("cells", "highlight the max joltage selection of a number of cells (repeatable, default 2 and 12)", func(s string) error {
+		cellCount, err := strconv.Atoi(s)
+		cellCounts = append(cellCounts, cellCount)
+		return err
+	})
+	format := fs.String("format", "auto", "output format: auto (ansi on a terminal unless NO_COLOR is set, text otherwise), "+strings.Join(day3.RenderFormats, ", "))
+	fs.Parse(args)
+
+	if *format == "auto" {
+		*format = day3.DetectFormat(os.Stdout)
+	}
+	r, err := day3.NewRenderer(os.Stdout, *format)
+	if err != nil {
+		return err
+	}
+	if len(cellCounts) == 0 {
+		cellCounts = []int{2, 12}
+	}
+
+	puzzleInput, err := input.Load(3, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+	banks, err := day3.ParseBatteryBanks(puzzleInput)
+	if err != nil {
+		return fmt.Errorf("day 3: parsing input: %w", err)
+	}
+	return r.Render(banks, cellCounts...)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to benchmark")
+	all := fs.Bool("all", false, "benchmark all registered days")
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	save := fs.String("save", "", "write the results as a baseline to this file")
+	baselinePath := fs.String("baseline", "", "compare the results against this baseline file")
+	threshold := fs.Float64("threshold", 10, "ns/op increase in percent that counts as a regression")
+	fs.Parse(args)
+
+	var days []int
+	switch {
+	case *all:
+		days = solver.Days()
+	case *day != 0:
+		days = []int{*day}
+	default:
+		return fmt.Errorf("either --day or --all is required")
+	}
+
+	var results []solver.BenchResult
+	for _, d := range days {
+		puzzleInput, err := input.Load(d, "", *inputDir)
+		if err != nil {
+			return err
+		}
+
+		dayResults, err := solver.Bench(d, puzzleInput)
+		if err != nil {
+			return err
+		}
+		results = append(results, dayResults...)
+	}
+
+	var baseline map[benchKey]solver.BenchResult
+	if *baselinePath != "" {
+		var err error
+		if baseline, err = readBaseline(*baselinePath); err != nil {
+			return err
+		}
+	}
+
+	regressions := printBenchTable(results, baseline, *threshold)
+
+	if *save != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*save, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("saving baseline: %w", err)
+		}
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%d benchmark(s) regressed by more than %.1f%%", regressions, *threshold)
+	}
+	return nil
+}
+
+type benchKey struct {
+	day  int
+	name string
+}
+
+func readBaseline(path string) (map[benchKey]solver.BenchResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+
+	var results []solver.BenchResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+
+	baseline := make(map[benchKey]solver.BenchResult, len(results))
+	for _, r := range results {
+		baseline[benchKey{r.Day, r.Name}] = r
+	}
+	return baseline, nil
+}
+
+// printBenchTable prints the results, with their change against the baseline if one is given,
+// and returns the number of regressions.
+func printBenchTable(results []solver.BenchResult, baseline map[benchKey]solver.BenchResult, threshold float64) (regressions int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if baseline == nil {
+		fmt.Fprintln(w, "day\tbenchmark\tns/op\tB/op\tallocs/op\t")
+	} else {
+		fmt.Fprintln(w, "day\tbenchmark\tns/op\tΔ ns/op\tB/op\tΔ B/op\tallocs/op\tΔ allocs/op\t")
+	}
+
+	for _, r := range results {
+		if baseline == nil {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t\n", r.Day, r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+			continue
+		}
+
+		base, ok := baseline[benchKey{r.Day, r.Name}]
+		if !ok {
+			fmt.Fprintf(w, "%d\t%s\t%d\tnew\t%d\tnew\t%d\tnew\t\n", r.Day, r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+			continue
+		}
+
+		nsDelta := percentChange(base.NsPerOp, r.NsPerOp)
+		marker := ""
+		if nsDelta > threshold {
+			marker = " !"
+			regressions++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%+.1f%%%s\t%d\t%+.1f%%\t%d\t%+.1f%%\t\n", r.Day, r.Name,
+			r.NsPerOp, nsDelta, marker,
+			r.BytesPerOp, percentChange(base.BytesPerOp, r.BytesPerOp),
+			r.AllocsPerOp, percentChange(base.AllocsPerOp, r.AllocsPerOp))
+	}
+	w.Flush()
+	return regressions
+}
+
+func percentChange(old, new int64) float64 {
+	if old == 0 {
+		if new == 0 {
+			return 0
+		}
+		return 100
+	}
+	return float64(new-old) / float64(old) * 100
+}
@@ -0,0 +1,12 @@
+package main
+
+// register all days with the solver registry
+import (
+	_ "advent_of_code/day1"
+	_ "advent_of_code/day2"
+	_ "advent_of_code/day3"
+	_ "advent_of_code/day4"
+	_ "advent_of_code/day5"
+	_ "advent_of_code/day6"
+	_ "advent_of_code/day7"
+)
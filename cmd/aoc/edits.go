@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"advent_of_code/day1"
+	"advent_of_code/input"
+)
+
+// editsCommand finds edits to the day 1 instructions that make a part produce the target answer.
+func editsCommand(args []string) error {
+	fs := flag.NewFlagSet("edits", flag.ExitOnError)
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	part := fs.Int("part", 1, "part whose answer should change: 1 counts zero hits, 2 zero crossings")
+	target := fs.Int("target", -1, "the wanted answer")
+	fs.Parse(args)
+
+	if *target < 0 {
+		return errors.New("--target is required")
+	}
+	metric := day1.ZeroHits
+	switch *part {
+	case 1:
+	case 2:
+		metric = day1.ZeroCrossings
+	default:
+		return fmt.Errorf("invalid part %d", *part)
+	}
+
+	puzzleInput, err := input.Load(1, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+	moves, err := day1.ParseMoves(puzzleInput)
+	if err != nil {
+		return fmt.Errorf("day 1: parsing input: %w", err)
+	}
+
+	turns, err := day1.ResolveTurns(moves)
+	if err != nil {
+		return fmt.Errorf("day 1: %w", err)
+	}
+	edits, err := day1.FindEdits(turns, metric, *target)
+	if err != nil {
+		return err
+	}
+	for _, e := range edits {
+		fmt.Println(e)
+	}
+	fmt.Printf("%d edit(s) for %d %s\n", len(edits), *target, metric)
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"advent_of_code/day2"
+	"advent_of_code/input"
+)
+
+// idsCommand sums the invalid day 2 IDs with arbitrary precision, in any radix.
+func idsCommand(args []string) error {
+	fs := flag.NewFlagSet("ids", flag.ExitOnError)
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	radix := fs.Int("radix", 10, "radix of the IDs, 2 to 36")
+	var rules []day2.IDRule
+	fs.Func("rule", "sum the IDs matching a rule instead of the puzzle's parts, e.g. 'and(repeats(2), not(palindrome))' (repeatable)", func(s string) error {
+		rule, err := day2.ParseRule(s)
+		rules = append(rules, rule)
+		return err
+	})
+	workers := fs.Int("workers", runtime.GOMAXPROCS(0), "number of goroutines checking IDs")
+	list := fs.Bool("list", false, "print every invalid ID before the sum")
+	explain := fs.Bool("explain", false, "print every invalid ID with the block and repetitions that make it invalid")
+	format := fs.String("format", "text", "explain format: text or json (one object per line)")
+	semanticsName := fs.String("semantics", "per-range", "how IDs in overlapping ranges count: per-range or union")
+	fs.Parse(args)
+
+	semantics, err := day2.ParseSemantics(*semanticsName)
+	if err != nil {
+		return err
+	}
+
+	puzzleInput, err := input.Load(2, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+	ranges, err := day2.ParseRanges(puzzleInput, *radix)
+	if err != nil {
+		return fmt.Errorf("day 2: parsing input: %w", err)
+	}
+	ranges, merged, err := semantics.Apply(ranges)
+	if err != nil {
+		return err
+	}
+	// the notices go to stderr, so they do not mix with listed IDs or json
+	for _, m := range merged {
+		switch {
+		case semantics == day2.Union && len(m.Inputs) > 1:
+			fmt.Fprintf(os.Stderr, "merged ranges %s into %s-%s\n", formatIndexes(m.Inputs), m.Start.Text(*radix), m.End.Text(*radix))
+		case semantics == day2.PerRange && len(m.Overlapping) > 0:
+			fmt.Fprintf(os.Stderr, "ranges %s overlap within %s-%s, their common IDs count once per range\n", formatIndexes(m.Overlapping), m.Start.Text(*radix), m.End.Text(*radix))
+		}
+	}
+
+	names := make([]string, len(rules))
+	for i, rule := range rules {
+		names[i] = rule.String()
+	}
+	if len(rules) == 0 {
+		rules = []day2.IDRule{day2.PartOneRule, day2.PartTwoRule}
+		names = []string{"Part One", "Part Two"}
+	}
+
+	// stop the workers on ctrl-c
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	for i, rule := range rules {
+		var emit func(day2.FoundID) error
+		switch {
+		case *explain:
+			ew, err := day2.NewExplainWriter(out, *format, ranges, *radix)
+			if err != nil {
+				return err
+			}
+			emit = day2.ExplainIDs(ew, rule)
+		case *list:
+			emit = day2.WriteIDs(out, *radix)
+		}
+		e := &day2.Evaluator{Rule: rule, Radix: *radix, Workers: *workers}
+		sum, err := e.Evaluate(ctx, ranges, emit)
+		if err != nil {
+			return err
+		}
+
+		if *explain && *format == "json" {
+			data, err := json.Marshal(map[string]string{"rule": names[i], "sum": sum.Text(*radix)})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s\n", data)
+			continue
+		}
+		fmt.Fprintf(out, "Day 2 - %s: %s\n", names[i], sum.Text(*radix))
+	}
+	return nil
+}
+
+// formatIndexes lists 0 based indexes as 1 based numbers, e.g. "1, 3, 4".
+func formatIndexes(indexes []int) string {
+	s := make([]string, len(indexes))
+	for i, index := range indexes {
+		s[i] = strconv.Itoa(index + 1)
+	}
+	return strings.Join(s, ", ")
+}
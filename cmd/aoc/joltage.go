@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"advent_of_code/day3"
+	"advent_of_code/input"
+)
+
+// joltageCommand sums the day 3 max joltages of any number of cells with arbitrary precision.
+func joltageCommand(args []string) error {
+	fs := flag.NewFlagSet("joltage", flag.ExitOnError)
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	cells := fs.Int("cells", 12, "number of cells to turn on in every bank")
+	fs.Parse(args)
+
+	puzzleInput, err := input.Load(3, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+	banks, err := day3.ParseBatteryBanks(puzzleInput)
+	if err != nil {
+		return fmt.Errorf("day 3: parsing input: %w", err)
+	}
+	sum, err := day3.SumMaxJoltageBig(banks, *cells)
+	if err != nil {
+		return err
+	}
+	fmt.Println(sum)
+	return nil
+}
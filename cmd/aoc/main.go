@@ -0,0 +1,65 @@
+// Command aoc runs the Advent of Code solvers.
+//
+// Usage:
+//
+//	aoc run --day 5 --part 2 [input path | -]...
+//	aoc run --all [--inputs dir] [--format text|json|csv]
+//	aoc bench --all [--save baseline.json] [--baseline baseline.json]
+//	AOC_SESSION=... aoc submit --day 5 --part 2
+//	aoc trace [--format text|json] [--summary] [input path | -]
+//	aoc edits --part 1 --target 5 [input path | -]
+//	aoc ids [--radix 16] [--semantics per-range|union] [--rule "or(repeats(3), palindrome)"]... [--list | --explain [--format text|json]] [--workers 4] [input path | -]
+//	aoc joltage --cells 300 [input path | -]
+//	aoc banks [--cells 2]... [--format auto|text|ansi|html|svg] [input path | -]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{name: "run", usage: "solve one day (--day N [--part P] [path | -]...) or all days (--all)", run: runCommand},
+	{name: "bench", usage: "benchmark one day (--day N) or all days (--all), optionally against a --baseline", run: benchCommand},
+	{name: "submit", usage: "solve and submit one part (--day N --part P), refusing answers known to be wrong", run: submitCommand},
+	{name: "trace", usage: "stream every day 1 dial rotation as text or json (--format, --summary)", run: traceCommand},
+	{name: "edits", usage: "find day 1 instruction edits that make a part (--part) answer --target; beyond a single edit this takes minutes and a gigabyte for a full input and a --target in the thousands", run: editsCommand},
+	{name: "ids", usage: "sum the day 2 invalid IDs of any size, in any --radix, per range or over their union (--semantics), by any --rule, listing (--list) or explaining (--explain) the IDs", run: idsCommand},
+	{name: "joltage", usage: "sum the day 3 max joltages of any number of --cells without overflowing", run: joltageCommand},
+	{name: "banks", usage: "show the day 3 banks with the max joltage selections of any --cells highlighted, in colour or as html or svg", run: banksCommand},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
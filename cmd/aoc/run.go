@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"advent_of_code/input"
+	"advent_of_code/report"
+	"advent_of_code/solver"
+	"advent_of_code/submit"
+)
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve")
+	part := fs.Int("part", 0, "part to solve (1 or 2); both if omitted")
+	all := fs.Bool("all", false, "solve all registered days")
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	format := fs.String("format", "text", "output format: "+strings.Join(report.Formats, ", "))
+	historyPath := fs.String("history", submit.DefaultHistoryPath, "submission history used to check the answers of the default inputs")
+	fs.Parse(args)
+
+	// optional input paths, "-" reads from stdin
+	paths := fs.Args()
+
+	var days []int
+	switch {
+	case *all:
+		if len(paths) > 0 {
+			return fmt.Errorf("input paths can only be given for a single day")
+		}
+		days = solver.Days()
+	case *day != 0:
+		days = []int{*day}
+	default:
+		return fmt.Errorf("either --day or --all is required")
+	}
+
+	var parts []int
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	out, err := report.NewWriter(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) > 1 {
+		err = runInputs(out, *day, paths, parts)
+	} else {
+		err = runDays(out, checkHistory(*historyPath, fs.Arg(0)), days, fs.Arg(0), *inputDir, parts)
+	}
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// checkHistory loads the history to check the answers of the default inputs. Checking is optional,
+// so a history that cannot be read only prints a warning and nothing is checked.
+func checkHistory(historyPath, path string) *submit.History {
+	if path != "" {
+		return &submit.History{}
+	}
+	history, err := submit.LoadHistory(historyPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: not checking answers:", err)
+		return &submit.History{}
+	}
+	return history
+}
+
+// runDays solves the given days one after another. Answers of the default inputs are checked against the history.
+func runDays(out report.Writer, history *submit.History, days []int, path, inputDir string, parts []int) error {
+	for _, d := range days {
+		puzzleInput, err := input.Load(d, path, inputDir)
+		if err != nil {
+			return err
+		}
+
+		results, err := solver.Run(d, puzzleInput, parts...)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			record := report.NewRecord(r, path)
+			if path == "" {
+				record.Check = history.Check(r.Day, r.Part, r.Answer).Describe()
+			}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runInputs solves several inputs of a single day concurrently.
+func runInputs(out report.Writer, day int, paths []string, parts []int) error {
+	puzzleInputs := make([]string, len(paths))
+	for i, path := range paths {
+		var err error
+		puzzleInputs[i], err = input.Load(day, path, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	results, err := solver.RunMany(day, puzzleInputs, parts...)
+	if err != nil {
+		return err
+	}
+
+	for i, inputResults := range results {
+		for _, r := range inputResults {
+			if err := out.Write(report.NewRecord(r, paths[i])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+	"advent_of_code/submit"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to submit")
+	part := fs.Int("part", 0, "part to submit (1 or 2)")
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	historyPath := fs.String("history", submit.DefaultHistoryPath, "submission history file")
+	server := fs.String("server", submit.DefaultBaseURL, "base URL of the answer server")
+	year := fs.Int("year", 2025, "event year")
+	fs.Parse(args)
+
+	if *day == 0 || (*part != 1 && *part != 2) {
+		return fmt.Errorf("--day and --part (1 or 2) are required")
+	}
+
+	session := os.Getenv("AOC_SESSION")
+	if session == "" {
+		return fmt.Errorf("AOC_SESSION must hold the session cookie")
+	}
+
+	puzzleInput, err := input.Load(*day, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+
+	results, err := solver.Run(*day, puzzleInput, *part)
+	if err != nil {
+		return err
+	}
+	answer := results[0].Answer
+
+	history, err := submit.LoadHistory(*historyPath)
+	if err != nil {
+		return err
+	}
+
+	s := &submit.Submitter{
+		History: history,
+		Client:  &submit.HTTPClient{BaseURL: *server, Year: *year, Session: session},
+	}
+	verdict, err := s.Submit(context.Background(), *day, *part, answer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Day %d - Part %d: %d - %s\n", *day, *part, answer, verdict)
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"advent_of_code/day1"
+	"advent_of_code/input"
+)
+
+// traceCommand streams the instructions through the dial, so arbitrarily large inputs are traced in constant memory.
+func traceCommand(args []string) error {
+	fs := flag.NewFlagSet("trace", flag.ExitOnError)
+	inputDir := fs.String("inputs", input.Dir(), "directory containing the dayNN.txt inputs")
+	format := fs.String("format", "text", "output format: text or json")
+	summary := fs.Bool("summary", false, "only print the final step")
+	fs.Parse(args)
+
+	r, err := input.Open(1, fs.Arg(0), *inputDir)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	tw, err := day1.NewTraceWriter(out, *format)
+	if err != nil {
+		return err
+	}
+
+	dec := day1.NewDecoder(r)
+	tracer := day1.NewTracer()
+	var last day1.TraceStep
+	for {
+		m, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("day 1: parsing input: %w", err)
+		}
+
+		last, err = tracer.Apply(m)
+		if err != nil {
+			line, column := dec.Position()
+			return fmt.Errorf("day 1: %w", input.Errorf(line, column, "%w", err))
+		}
+		if !*summary {
+			if err := tw.Write(last); err != nil {
+				return err
+			}
+		}
+	}
+
+	if *summary && last.Index > 0 {
+		return tw.Write(last)
+	}
+	return nil
+}
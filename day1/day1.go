@@ -0,0 +1,92 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"advent_of_code/solver"
+)
+
+const (
+	maxCount      = 100
+	startPosition = 50
+)
+
+// parseInput processes the input and returns the moves it describes.
+func parseInput(rawInput string) ([]Move, error) {
+	dec := NewDecoder(strings.NewReader(rawInput))
+	moves := []Move{}
+	for {
+		m, err := dec.Next()
+		if err == io.EOF {
+			return moves, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+}
+
+// ParseMoves parses the puzzle input into moves.
+func ParseMoves(input string) ([]Move, error) {
+	return parseInput(input)
+}
+
+// newStandardDial returns the puzzle's dial: 100 positions, starting at 50 and watching 0.
+func newStandardDial() *Dial {
+	d, _ := NewDial(maxCount, startPosition, 0)
+	return d
+}
+
+// executeMoves applies all moves to a fresh standard dial.
+func executeMoves(moves []Move) (*Dial, error) {
+	d := newStandardDial()
+	for i, m := range moves {
+		if err := m.apply(d); err != nil {
+			return nil, fmt.Errorf("move %d (%v): %w", i+1, m, err)
+		}
+	}
+	return d, nil
+}
+
+// executeTurnsCountZeroHits processes the list of moves and returns how often the dial hit 0
+func executeTurnsCountZeroHits(moves []Move) (int, error) {
+	d, err := executeMoves(moves)
+	if err != nil {
+		return 0, err
+	}
+	return d.Hits[0], nil
+}
+
+// executeTurnsCrossZero processes the list of moves and returns how often the dial pointed at 0
+func executeTurnsCrossZero(moves []Move) (int, error) {
+	d, err := executeMoves(moves)
+	if err != nil {
+		return 0, err
+	}
+	return d.Crossings[0], nil
+}
+
+func init() {
+	solver.Register(1, func() solver.Solver { return &Solver{} })
+}
+
+// Solver counts how often the dial hits (part one) and crosses (part two) zero.
+type Solver struct {
+	moves []Move
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.moves, err = ParseMoves(input)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return executeTurnsCountZeroHits(s.moves)
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return executeTurnsCrossZero(s.moves)
+}
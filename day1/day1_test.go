@@ -0,0 +1,362 @@
+package day1
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"advent_of_code/input"
+	"advent_of_code/solver/solvertest"
+)
+
+const example = `L68
+L30
+R48
+L5
+R60
+L55
+L1
+L99
+R14
+L82`
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 1, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 3, PartTwo: 6},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 3, PartTwo: 6},
+		{Name: "land on zero", Input: "L50", PartOne: 1, PartTwo: 1},
+		{Name: "full turns", Input: "R1000", PartOne: 0, PartTwo: 10},
+		{Name: "leave zero to the left", Input: "R50\nL1", PartOne: 1, PartTwo: 1},
+		{Name: "pass zero twice", Input: "L150", PartOne: 1, PartTwo: 2},
+		{Name: "stay on zero", Input: "L50\nR100\nL100", PartOne: 3, PartTwo: 3},
+		{Name: "absolute tie turns right", Input: "=0", PartOne: 1, PartTwo: 1},
+		{Name: "absolute turns left", Input: "L10\n=0\n=99", PartOne: 1, PartTwo: 1},
+		{Name: "absolute to current position", Input: "L50\n=0", PartOne: 2, PartTwo: 1},
+		{Name: "absolute to the last position", Input: "=99\n=0", PartOne: 1, PartTwo: 1},
+		{Name: "repeat", Input: "4x(R25)\n2xL100", PartOne: 1, PartTwo: 3},
+		{Name: "macro", Input: "def half(L50) # to zero\nhalf\n2x(R50 half)", PartOne: 3, PartTwo: 3},
+		{Name: "blank lines and comments", Input: "# generated\n\nL68\nL30 # first\n\n" + example[8:] + "\n\n", PartOne: 3, PartTwo: 6},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 1, []solvertest.ErrorCase{
+		{Name: "unknown direction", Input: "R1\nX5", Line: 2, Column: 1},
+		{Name: "invalid number", Input: "L1\nR1\nR1x", Line: 3, Column: 2},
+		{Name: "indented", Input: "R1\n  X5", Line: 2, Column: 3},
+		{Name: "after comment", Input: "# header\nR1\n\nL1y # comment", Line: 4, Column: 2},
+		{Name: "second on line", Input: "R1 L2 Q3", Line: 1, Column: 7},
+		{Name: "inside repeat", Input: "R1\n2x(R1\n L2 Rx)", Line: 3, Column: 6},
+		{Name: "split repeat", Input: "2xRy", Line: 1, Column: 4},
+		{Name: "unclosed group", Input: "R1\n 3x(R1\nL2", Line: 2, Column: 4},
+		{Name: "unexpected close", Input: "R1 )", Line: 1, Column: 4},
+		{Name: "missing repeat body", Input: "R1 2x", Line: 1, Column: 4},
+		{Name: "undefined macro", Input: "def a R1\na b", Line: 2, Column: 3},
+		{Name: "macro used before definition", Input: "spin\ndef spin R1", Line: 1, Column: 1},
+		{Name: "macro redefined", Input: "def a R1\ndef a R2", Line: 2, Column: 5},
+		{Name: "invalid macro name", Input: "def 2a R1", Line: 1, Column: 5},
+		{Name: "nested definition", Input: "(def a R1)", Line: 1, Column: 2},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 1)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 1, example)
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []Move
+		wantErr bool
+	}{
+		{input: "R1\nL2", want: []Move{{Turn: 1}, {Turn: -2}}},
+		{input: "L0", want: []Move{{Turn: 0}}},
+		{input: "X1", wantErr: true},
+		{input: "R", wantErr: true},
+		{input: "R1\n\nL2\n", want: []Move{{Turn: 1}, {Turn: -2}}},
+		{input: "# only a comment", want: []Move{}},
+		{input: "  R3  # three\r\nL4", want: []Move{{Turn: 3}, {Turn: -4}}},
+		{input: "R1 L2 =30", want: []Move{{Turn: 1}, {Turn: -2}, {Absolute: true, Position: 30}}},
+		{input: "2x(R1 L2) 3xR4", want: []Move{{Turn: 1}, {Turn: -2}, {Turn: 1}, {Turn: -2}, {Turn: 4}, {Turn: 4}, {Turn: 4}}},
+		{input: "2x(R1\n  2x L2)\n0x(R9)", want: []Move{{Turn: 1}, {Turn: -2}, {Turn: -2}, {Turn: 1}, {Turn: -2}, {Turn: -2}}},
+		{input: "def spin(R1 L2)\nspin\ndef twice 2x spin\ntwice", want: []Move{{Turn: 1}, {Turn: -2}, {Turn: 1}, {Turn: -2}, {Turn: 1}, {Turn: -2}}},
+		{input: "1000000000000x()", want: []Move{}},
+		{input: "=-1", want: []Move{{Absolute: true, Position: -1}}},
+		{input: "=100", want: []Move{{Absolute: true, Position: 100}}},
+		{input: "(R1", wantErr: true},
+		{input: "R1)", wantErr: true},
+		{input: "3x", wantErr: true},
+		{input: "spin", wantErr: true},
+		{input: "def spin R1\ndef spin R2", wantErr: true},
+		{input: "(def spin R1)", wantErr: true},
+		{input: "def Spin R1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseInput(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseInput(%q): unexpected error state: %v", tt.input, err)
+			continue
+		}
+		if !tt.wantErr && !slices.Equal(got, tt.want) {
+			t.Errorf("parseInput(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseLongLines(t *testing.T) {
+	// both lines are longer than the 64 KB a bufio.Scanner accepts by default
+	long := strings.Repeat("R1 ", 40000) + "# " + strings.Repeat("x", 70000) + "\nL2"
+	moves, err := parseInput(long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moves) != 40001 || moves[40000] != (Move{Turn: -2}) {
+		t.Errorf("got %d moves ending in %v, want 40001 ending in L2", len(moves), moves[len(moves)-1])
+	}
+
+	_, err = parseInput(strings.Repeat("R1 ", 40000) + "Q3")
+	var parseErr *input.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 1 || parseErr.Column != 120001 {
+		t.Errorf("got %v, want a parse error at 1:120001", err)
+	}
+}
+
+// clickDial simulates turns one click at a time.
+func clickDial(modulus, start int, targets, turns []int) (hits, crossings []int) {
+	hits = make([]int, len(targets))
+	crossings = make([]int, len(targets))
+	position := start
+	for _, turn := range turns {
+		step := 1
+		if turn < 0 {
+			step = -1
+		}
+		for range max(turn, -turn) {
+			position = ((position+step)%modulus + modulus) % modulus
+			for i, target := range targets {
+				if position == target {
+					crossings[i]++
+				}
+			}
+		}
+		for i, target := range targets {
+			if position == target {
+				hits[i]++
+			}
+		}
+	}
+	return hits, crossings
+}
+
+func TestDialMatchesClickSimulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 500 {
+		modulus := 1 + rng.Intn(20)
+		start := rng.Intn(modulus)
+		targets := []int{rng.Intn(modulus), rng.Intn(modulus), 0}
+		turns := make([]int, rng.Intn(30))
+		for i := range turns {
+			turns[i] = rng.Intn(6*modulus) - 3*modulus
+		}
+
+		d, err := NewDial(modulus, start, targets...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Execute(turns)
+
+		wantHits, wantCrossings := clickDial(modulus, start, targets, turns)
+		if !slices.Equal(d.Hits, wantHits) || !slices.Equal(d.Crossings, wantCrossings) {
+			t.Fatalf("dial %d from %d, targets %v, turns %v: got hits %v crossings %v, want %v %v",
+				modulus, start, targets, turns, d.Hits, d.Crossings, wantHits, wantCrossings)
+		}
+	}
+}
+
+func TestLock(t *testing.T) {
+	small, err := NewDial(10, 0, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := NewDial(100, 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Lock{small, large}.Execute([]int{15, -30, 65})
+
+	if !slices.Equal(small.Hits, []int{1, 2}) || !slices.Equal(small.Crossings, []int{11, 11}) {
+		t.Errorf("small dial: got hits %v crossings %v", small.Hits, small.Crossings)
+	}
+	if !slices.Equal(large.Hits, []int{1}) || !slices.Equal(large.Crossings, []int{1}) {
+		t.Errorf("large dial: got hits %v crossings %v", large.Hits, large.Crossings)
+	}
+}
+
+func TestDialSet(t *testing.T) {
+	tests := []struct {
+		modulus, start, position int
+		want                     int
+	}{
+		{modulus: 100, start: 50, position: 0, want: 50},
+		{modulus: 100, start: 40, position: 0, want: -40},
+		{modulus: 100, start: 10, position: 10, want: 0},
+		{modulus: 7, start: 0, position: 3, want: 3},
+		{modulus: 7, start: 0, position: 4, want: -3},
+	}
+
+	for _, tt := range tests {
+		got, _ := NewDial(tt.modulus, tt.start, 0)
+		if err := got.Set(tt.position); err != nil {
+			t.Errorf("dial of size %d at %d: Set(%d): %v", tt.modulus, tt.start, tt.position, err)
+			continue
+		}
+		want, _ := NewDial(tt.modulus, tt.start, 0)
+		want.Turn(tt.want)
+		if got.Position != want.Position || got.Hits[0] != want.Hits[0] || got.Crossings[0] != want.Crossings[0] {
+			t.Errorf("dial of size %d at %d: Set(%d) = %+v, want turn %d (%+v)", tt.modulus, tt.start, tt.position, got, tt.want, want)
+		}
+	}
+}
+
+func TestSetOffTheDial(t *testing.T) {
+	d, _ := NewDial(10, 5, 0)
+	for _, position := range []int{-1, 10, 305} {
+		if err := d.Set(position); err == nil {
+			t.Errorf("Set(%d) on a dial of size 10: expected an error", position)
+		}
+	}
+	if d.Position != 5 || d.Hits[0] != 0 || d.Crossings[0] != 0 {
+		t.Errorf("failed sets turned the dial: %+v", d)
+	}
+
+	large, _ := NewDial(100, 50, 0)
+	if err := (Lock{large, d}).Set(50); err == nil {
+		t.Errorf("Set(50) on a lock with a dial of size 10: expected an error")
+	}
+	if large.Position != 50 || large.Hits[0] != 0 {
+		t.Errorf("a failed lock set turned the large dial: %+v", large)
+	}
+}
+
+func TestNewDialValidation(t *testing.T) {
+	for _, args := range [][3]int{{0, 0, 0}, {10, 10, 0}, {10, 0, -1}, {10, 0, 10}} {
+		if _, err := NewDial(args[0], args[1], args[2]); err == nil {
+			t.Errorf("NewDial(%d, %d, %d): expected an error", args[0], args[1], args[2])
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	moves, err := ParseMoves(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := Trace(moves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != len(moves) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(moves))
+	}
+
+	want := TraceStep{Index: 3, Instruction: "R48", Start: 52, End: 0, ZeroPasses: 1, Hit: true, TotalHits: 1, TotalPasses: 2}
+	if steps[2] != want {
+		t.Errorf("step 3: got %+v, want %+v", steps[2], want)
+	}
+
+	last := steps[len(steps)-1]
+	if last.TotalHits != 3 || last.TotalPasses != 6 {
+		t.Errorf("totals: got %d hits and %d passes, want 3 and 6", last.TotalHits, last.TotalPasses)
+	}
+}
+
+// repeatReader streams text n times without holding all of it in memory.
+type repeatReader struct {
+	text string
+	n    int
+	rest string
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	if r.rest == "" {
+		if r.n == 0 {
+			return 0, io.EOF
+		}
+		r.n--
+		r.rest = r.text
+	}
+	n := copy(p, r.rest)
+	r.rest = r.rest[n:]
+	return n, nil
+}
+
+func TestFeed(t *testing.T) {
+	const repeats = 1000
+	moves, err := ParseMoves(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := executeMoves(slices.Repeat(moves, repeats))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := newStandardDial()
+	if err := Feed(&repeatReader{text: "# block\n" + example + "\n\n", n: repeats}, got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Hits, want.Hits) || !slices.Equal(got.Crossings, want.Crossings) || got.Position != want.Position {
+		t.Errorf("Feed: got %+v, want %+v", got, want)
+	}
+}
+
+func TestFeedError(t *testing.T) {
+	for _, tt := range []struct {
+		input        string
+		line, column int
+	}{
+		{input: "R1\n# comment\n\n  L2\nR3z", line: 5, column: 2},
+		{input: "R1\n =130", line: 2, column: 2},
+		{input: "=-4", line: 1, column: 1},
+		{input: "def far =100\nR1 2x(L1 far)", line: 1, column: 9},
+	} {
+		err := Feed(strings.NewReader(tt.input), Lock{newStandardDial()})
+		var parseErr *input.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != tt.line || parseErr.Column != tt.column {
+			t.Errorf("Feed(%q): got error %v, want a parse error at line %d, column %d", tt.input, err, tt.line, tt.column)
+		}
+	}
+}
+
+func TestFeedLargeDial(t *testing.T) {
+	d, _ := NewDial(1000, 0, 0)
+	if err := Feed(strings.NewReader("=500 =999 R1"), d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Position != 0 || d.Hits[0] != 1 {
+		t.Errorf("got %+v, want one hit at 0", d)
+	}
+}
+
+func TestSolverPositionOffTheDial(t *testing.T) {
+	s := &Solver{}
+	if err := s.Parse("R1\n=100"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PartOne(); err == nil {
+		t.Errorf("part one: expected an error for position 100")
+	}
+	if _, err := s.PartTwo(); err == nil {
+		t.Errorf("part two: expected an error for position 100")
+	}
+}
@@ -0,0 +1,427 @@
+package day1
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"advent_of_code/input"
+)
+
+// commentPrefix starts a comment that runs until the end of the line.
+const commentPrefix = '#'
+
+// Move is a single instruction, either a relative turn (> 0 right, < 0 left) or, if Absolute is
+// set, a turn to Position.
+type Move struct {
+	Turn     int
+	Absolute bool
+	Position int
+}
+
+func (m Move) String() string {
+	if m.Absolute {
+		return "=" + strconv.Itoa(m.Position)
+	}
+	if m.Turn < 0 {
+		return "L" + strconv.Itoa(-m.Turn)
+	}
+	return "R" + strconv.Itoa(m.Turn)
+}
+
+func (m Move) apply(t Turner) error {
+	if m.Absolute {
+		return t.Set(m.Position)
+	}
+	t.Turn(m.Turn)
+	return nil
+}
+
+// Turner is anything that can be turned, like a Dial or a Lock. Set fails for positions the
+// turner does not have.
+type Turner interface {
+	Turn(turn int)
+	Set(position int) error
+}
+
+// Decoder reads instructions from a stream. Besides R<n> and L<n> the language knows
+//
+//	=<n>            turn the shortest way to position n of the dial (right on a tie)
+//	<k>x <instr>    repeat an instruction k times, e.g. 3x(R10 L5) or 2xR1
+//	( ... )         group instructions, groups may span several lines
+//	def <name> ...  define a macro at the top level, used by writing its name
+//
+// Instructions are separated by whitespace, # starts a comment. The input is read a word at a
+// time, top level instructions are decoded as they are read and repeats are expanded lazily, so
+// arbitrarily large instruction files with lines of any length are decoded in constant memory.
+type Decoder struct {
+	r *bufio.Reader
+	// line and column are the position of the last byte read
+	line, column int
+	tokens       []token
+	buf          []token
+	word         []byte
+	macros       map[string]*block
+	stack        []frame
+	// moveLine and moveColumn are the position of the instruction of the last move
+	moveLine, moveColumn int
+}
+
+type token struct {
+	text         string
+	line, column int
+}
+
+// block is a group of statements that is executed count times.
+type block struct {
+	count int
+	body  []statement
+	// empty is set if executing the block produces no moves at all.
+	empty bool
+}
+
+// statement is either a single move, read at line and column, or a nested block.
+type statement struct {
+	move         Move
+	line, column int
+	block        *block
+}
+
+// frame tracks the execution of a block.
+type frame struct {
+	block     *block
+	next      int
+	remaining int
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), line: 1, macros: map[string]*block{}}
+}
+
+func newBlock(count int, body []statement) *block {
+	empty := true
+	for _, st := range body {
+		if st.block == nil || !st.block.empty {
+			empty = false
+		}
+	}
+	return &block{count: count, body: body, empty: empty || count == 0}
+}
+
+// Next returns the next move. It returns io.EOF once all instructions are read.
+func (d *Decoder) Next() (Move, error) {
+	for {
+		if len(d.stack) == 0 {
+			tok, err := d.readToken()
+			if err != nil {
+				return Move{}, err
+			}
+			if tok.text == "def" {
+				if err := d.define(tok); err != nil {
+					return Move{}, err
+				}
+				continue
+			}
+
+			st, err := d.statement(tok)
+			if err != nil {
+				return Move{}, err
+			}
+			if st.block == nil {
+				d.moveLine, d.moveColumn = st.line, st.column
+				return st.move, nil
+			}
+			d.push(st.block)
+			continue
+		}
+
+		f := &d.stack[len(d.stack)-1]
+		if f.next == len(f.block.body) {
+			f.remaining--
+			f.next = 0
+			if f.remaining == 0 {
+				d.stack = d.stack[:len(d.stack)-1]
+			}
+			continue
+		}
+
+		st := f.block.body[f.next]
+		f.next++
+		if st.block == nil {
+			d.moveLine, d.moveColumn = st.line, st.column
+			return st.move, nil
+		}
+		d.push(st.block)
+	}
+}
+
+// Position returns the line and column of the instruction of the move Next returned last. Moves of
+// repeats and macros are at the instruction inside the repeat or the definition.
+func (d *Decoder) Position() (line, column int) {
+	return d.moveLine, d.moveColumn
+}
+
+func (d *Decoder) push(b *block) {
+	if !b.empty {
+		d.stack = append(d.stack, frame{block: b, remaining: b.count})
+	}
+}
+
+// statement parses the instruction starting with tok.
+func (d *Decoder) statement(tok token) (statement, error) {
+	switch {
+	case tok.text == "(":
+		body, err := d.group(tok)
+		if err != nil {
+			return statement{}, err
+		}
+		return statement{block: newBlock(1, body)}, nil
+	case tok.text == ")":
+		return statement{}, input.Errorf(tok.line, tok.column, "unexpected ')'")
+	case tok.text == "def":
+		return statement{}, input.Errorf(tok.line, tok.column, "macros must be defined at the top level")
+	case isRepeat(tok.text):
+		count, err := strconv.Atoi(tok.text[:len(tok.text)-1])
+		if err != nil {
+			return statement{}, input.Errorf(tok.line, tok.column, "invalid repeat count '%s': %w", tok.text, err)
+		}
+		next, err := d.readToken()
+		if err == io.EOF {
+			return statement{}, input.Errorf(tok.line, tok.column, "repeat '%s' is missing its instructions", tok.text)
+		}
+		if err != nil {
+			return statement{}, err
+		}
+		st, err := d.statement(next)
+		if err != nil {
+			return statement{}, err
+		}
+		return statement{block: newBlock(count, []statement{st})}, nil
+	case isName(tok.text):
+		b, ok := d.macros[tok.text]
+		if !ok {
+			return statement{}, input.Errorf(tok.line, tok.column, "undefined macro '%s'", tok.text)
+		}
+		return statement{block: b}, nil
+	default:
+		m, err := parseMove(tok.text, tok.line, tok.column)
+		return statement{move: m, line: tok.line, column: tok.column}, err
+	}
+}
+
+// group parses the statements up to the ')' matching open.
+func (d *Decoder) group(open token) ([]statement, error) {
+	body := []statement{}
+	for {
+		tok, err := d.readToken()
+		if err == io.EOF {
+			return nil, input.Errorf(open.line, open.column, "unclosed '('")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if tok.text == ")" {
+			return body, nil
+		}
+
+		st, err := d.statement(tok)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, st)
+	}
+}
+
+// define parses a macro definition, def is the already read "def" token.
+func (d *Decoder) define(def token) error {
+	name, err := d.readToken()
+	if err == io.EOF {
+		return input.Errorf(def.line, def.column, "macro definition is missing a name")
+	}
+	if err != nil {
+		return err
+	}
+	if !isName(name.text) || name.text == "def" {
+		return input.Errorf(name.line, name.column, "invalid macro name '%s'", name.text)
+	}
+	if _, ok := d.macros[name.text]; ok {
+		return input.Errorf(name.line, name.column, "macro '%s' is already defined", name.text)
+	}
+
+	tok, err := d.readToken()
+	if err == io.EOF {
+		return input.Errorf(name.line, name.column, "macro '%s' is missing its instructions", name.text)
+	}
+	if err != nil {
+		return err
+	}
+	st, err := d.statement(tok)
+	if err != nil {
+		return err
+	}
+	d.macros[name.text] = newBlock(1, []statement{st})
+	return nil
+}
+
+// readToken returns the next token, reading more words as needed.
+func (d *Decoder) readToken() (token, error) {
+	for len(d.tokens) == 0 {
+		if err := d.readWord(); err != nil {
+			return token{}, err
+		}
+	}
+
+	tok := d.tokens[0]
+	d.tokens = d.tokens[1:]
+	return tok, nil
+}
+
+// readWord reads the next word or parenthesis into the tokens, skipping whitespace and comments.
+func (d *Decoder) readWord() error {
+	word := d.word[:0]
+	column := 0
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF && len(word) > 0 {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		d.column++
+
+		if c != '(' && c != ')' && c != ' ' && c != '\t' && c != '\r' && c != '\n' && c != commentPrefix {
+			if len(word) == 0 {
+				column = d.column
+			}
+			word = append(word, c)
+			continue
+		}
+		if len(word) > 0 {
+			// the delimiter is read again with the next word
+			d.r.UnreadByte()
+			d.column--
+			break
+		}
+
+		switch c {
+		case '(', ')':
+			d.buf = append(d.buf[:0], token{text: string(c), line: d.line, column: d.column})
+			d.tokens = d.buf
+			return nil
+		case commentPrefix:
+			if err := d.skipComment(); err != nil {
+				return err
+			}
+		case '\n':
+			d.line++
+			d.column = 0
+		}
+	}
+
+	d.word = word
+	d.buf = appendWord(d.buf[:0], string(word), d.line, column)
+	d.tokens = d.buf
+	return nil
+}
+
+// skipComment skips the rest of the line, including the newline.
+func (d *Decoder) skipComment() error {
+	for {
+		_, err := d.r.ReadSlice('\n')
+		switch err {
+		case bufio.ErrBufferFull:
+			continue
+		case nil:
+			d.line++
+			d.column = 0
+			return nil
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// appendWord appends a word, splitting off repeat prefixes like the "2x" of "2xR1".
+func appendWord(tokens []token, word string, line, column int) []token {
+	for {
+		digits := 0
+		for digits < len(word) && word[digits] >= '0' && word[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || digits+1 >= len(word) || word[digits] != 'x' {
+			return append(tokens, token{text: word, line: line, column: column})
+		}
+
+		tokens = append(tokens, token{text: word[:digits+1], line: line, column: column})
+		word = word[digits+1:]
+		column += digits + 1
+	}
+}
+
+func isRepeat(text string) bool {
+	if len(text) < 2 || text[len(text)-1] != 'x' {
+		return false
+	}
+	for _, c := range text[:len(text)-1] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isName reports whether text is a macro name: a lower case letter followed by lower case letters, digits or '_'.
+func isName(text string) bool {
+	for i, c := range text {
+		if !(c >= 'a' && c <= 'z' || i > 0 && (c >= '0' && c <= '9' || c == '_')) {
+			return false
+		}
+	}
+	return text != ""
+}
+
+// parseMove parses a single "R<n>", "L<n>" or "=<n>" instruction found at line and column.
+func parseMove(instr string, line, column int) (Move, error) {
+	direction := 0
+	switch {
+	case strings.HasPrefix(instr, "R"):
+		direction = 1
+	case strings.HasPrefix(instr, "L"):
+		direction = -1
+	case strings.HasPrefix(instr, "="):
+	default:
+		return Move{}, input.Errorf(line, column, "invalid instruction: '%s'", instr)
+	}
+
+	value, err := strconv.Atoi(instr[1:])
+	if err != nil {
+		return Move{}, input.Errorf(line, column+1, "invalid number in instruction '%s': %w", instr, err)
+	}
+	if direction == 0 {
+		return Move{Absolute: true, Position: value}, nil
+	}
+	return Move{Turn: direction * value}, nil
+}
+
+// Feed decodes all instructions of r and applies them to t as they are read. Positions the turner
+// does not have are reported as parse errors at their instruction.
+func Feed(r io.Reader, t Turner) error {
+	dec := NewDecoder(r)
+	for {
+		m, err := dec.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.apply(t); err != nil {
+			line, column := dec.Position()
+			return input.Errorf(line, column, "%w", err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package day1
+
+import "fmt"
+
+// Dial is a combination lock dial with the positions 0..Modulus-1. For every target position it
+// counts how often a turn ended on it (hits) and how often the dial pointed at it during or at the
+// end of a turn (crossings).
+type Dial struct {
+	Modulus   int
+	Position  int
+	Targets   []int
+	Hits      []int
+	Crossings []int
+}
+
+// NewDial returns a dial of the given size, starting at start and watching the given targets.
+func NewDial(modulus, start int, targets ...int) (*Dial, error) {
+	if modulus <= 0 {
+		return nil, fmt.Errorf("dial size must be positive, got %d", modulus)
+	}
+	if start < 0 || start >= modulus {
+		return nil, fmt.Errorf("start position %d is not on a dial of size %d", start, modulus)
+	}
+	for _, target := range targets {
+		if target < 0 || target >= modulus {
+			return nil, fmt.Errorf("target %d is not on a dial of size %d", target, modulus)
+		}
+	}
+
+	return &Dial{
+		Modulus:   modulus,
+		Position:  start,
+		Targets:   targets,
+		Hits:      make([]int, len(targets)),
+		Crossings: make([]int, len(targets)),
+	}, nil
+}
+
+// Turn rotates the dial, turn > 0 means turn right, < 0 means turn left.
+func (d *Dial) Turn(turn int) {
+	for i, target := range d.Targets {
+		d.Crossings[i] += countPasses(d.Position, turn, target, d.Modulus)
+	}
+
+	d.Position = ((d.Position+turn)%d.Modulus + d.Modulus) % d.Modulus
+
+	for i, target := range d.Targets {
+		if d.Position == target {
+			d.Hits[i]++
+		}
+	}
+}
+
+// Set turns the dial the shortest way to position, turning right if both ways are equally long.
+// Setting the dial to its current position is a turn by 0, which counts as a hit. It returns an
+// error without turning if position is not on the dial.
+func (d *Dial) Set(position int) error {
+	if err := d.checkPosition(position); err != nil {
+		return err
+	}
+	d.Turn(d.turnTo(position))
+	return nil
+}
+
+// checkPosition returns an error if position is not on the dial.
+func (d *Dial) checkPosition(position int) error {
+	if position < 0 || position >= d.Modulus {
+		return fmt.Errorf("position %d is not on a dial of size %d", position, d.Modulus)
+	}
+	return nil
+}
+
+// turnTo returns the turn Set uses to reach position.
+func (d *Dial) turnTo(position int) int {
+	turn := ((position-d.Position)%d.Modulus + d.Modulus) % d.Modulus
+	if turn > d.Modulus/2 {
+		turn -= d.Modulus
+	}
+	return turn
+}
+
+// Execute applies all turns in order.
+func (d *Dial) Execute(turns []int) {
+	for _, turn := range turns {
+		d.Turn(turn)
+	}
+}
+
+// countPasses returns how often the dial points at target while turning from position,
+// including the end position but not the start position.
+func countPasses(position, turn, target, modulus int) int {
+	if turn >= 0 {
+		// values in (position, position+turn]
+		return floorDiv(position+turn-target, modulus) - floorDiv(position-target, modulus)
+	}
+	// values in [position+turn, position)
+	return floorDiv(position-1-target, modulus) - floorDiv(position+turn-1-target, modulus)
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Lock is a set of dials that are turned in lockstep.
+type Lock []*Dial
+
+// Turn rotates every dial of the lock.
+func (l Lock) Turn(turn int) {
+	for _, d := range l {
+		d.Turn(turn)
+	}
+}
+
+// Set turns every dial of the lock the shortest way to position. It returns an error without
+// turning any dial if position is not on all of them.
+func (l Lock) Set(position int) error {
+	for _, d := range l {
+		if err := d.checkPosition(position); err != nil {
+			return err
+		}
+	}
+	for _, d := range l {
+		d.Turn(d.turnTo(position))
+	}
+	return nil
+}
+
+// Execute applies all turns in order to every dial of the lock.
+func (l Lock) Execute(turns []int) {
+	for _, turn := range turns {
+		l.Turn(turn)
+	}
+}
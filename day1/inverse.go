@@ -0,0 +1,499 @@
+package day1
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Metric selects the count the inverse solver aims for.
+type Metric int
+
+const (
+	// ZeroHits counts the turns that end on 0 (part one).
+	ZeroHits Metric = iota
+	// ZeroCrossings counts every time the dial points at 0 (part two).
+	ZeroCrossings
+)
+
+func (m Metric) String() string {
+	if m == ZeroCrossings {
+		return "zero crossings"
+	}
+	return "zero hits"
+}
+
+// Edit replaces the turn at Index (0 based) with To. Flipping the direction and changing the
+// magnitude are both expressed as a new turn value.
+type Edit struct {
+	Index int
+	From  int
+	To    int
+}
+
+func (e Edit) String() string {
+	return fmt.Sprintf("turn %d: %s -> %s", e.Index+1, Move{Turn: e.From}, Move{Turn: e.To})
+}
+
+// ResolveTurns converts moves into the relative turns they make on the standard dial.
+func ResolveTurns(moves []Move) ([]int, error) {
+	d := newStandardDial()
+	turns := make([]int, len(moves))
+	for i, m := range moves {
+		turns[i] = m.Turn
+		if m.Absolute {
+			if err := d.checkPosition(m.Position); err != nil {
+				return nil, fmt.Errorf("move %d (%v): %w", i+1, m, err)
+			}
+			turns[i] = d.turnTo(m.Position)
+		}
+		d.Turn(turns[i])
+	}
+	return turns, nil
+}
+
+// countMetric executes turns on a dial of the given size and returns the metric for target 0.
+func countMetric(turns []int, modulus, start int, metric Metric) int {
+	d, _ := NewDial(modulus, start, 0)
+	d.Execute(turns)
+	if metric == ZeroCrossings {
+		return d.Crossings[0]
+	}
+	return d.Hits[0]
+}
+
+// FindEdits returns a minimal set of edits to turns on the standard dial that make metric equal to
+// target. Among all minimal sets it returns one that changes the turns the least in total.
+//
+// A single edit is found in O(len(turns) * modulus) time. Targets that need more edits take
+// O(len(turns) * target * modulus) time and memory for 2*sqrt(len(turns)) layers of target*modulus
+// costs, which are minutes and about a gigabyte for a full puzzle input and a target in the thousands.
+func FindEdits(turns []int, metric Metric, target int) ([]Edit, error) {
+	return findEdits(turns, maxCount, startPosition, metric, target)
+}
+
+// editCost is the cost of a single edit. Costs add the number of edits times editCost to the total
+// change of the turns, so comparing costs compares the number of edits first.
+const editCost = 1 << 40
+
+const unreachable = math.MaxInt
+
+// findEdits runs a dynamic program over the turns, unless no or a single edit suffices. Its state
+// after a turn is the count so far and the position of the dial, packed into the index
+// count*modulus+position of a layer, which holds the cheapest cost to reach the state. Counts only
+// grow, so layers stop at the target.
+//
+// Only the layers at every sqrt(n)-th turn are kept; the layers in between are computed again while
+// walking back from the target to find the edits.
+func findEdits(turns []int, modulus, start int, metric Metric, target int) ([]Edit, error) {
+	if target < 0 {
+		return nil, fmt.Errorf("target must not be negative, got %d", target)
+	}
+	if countMetric(turns, modulus, start, metric) == target {
+		return []Edit{}, nil
+	}
+	if edit, result, ok := bestEdit(turns, modulus, start, metric, target); ok && result == target {
+		return []Edit{edit}, nil
+	}
+
+	steps := newLayerSteps(metric, modulus)
+	size := (target + 1) * modulus
+	layer, next := make([]int, size), make([]int, size)
+	for i := range layer {
+		layer[i] = unreachable
+	}
+	layer[start] = 0
+
+	blockSize := max(1, int(math.Sqrt(float64(len(turns)))))
+	checkpoints := [][]int{}
+	for i, turn := range turns {
+		if i%blockSize == 0 {
+			checkpoints = append(checkpoints, slices.Clone(layer))
+		}
+		steps.step(next, layer, turn)
+		layer, next = next, layer
+	}
+
+	state := -1
+	for position := range modulus {
+		if value := layer[target*modulus+position]; value != unreachable && (state < 0 || value < layer[state]) {
+			state = target*modulus + position
+		}
+	}
+	if state < 0 {
+		return nil, fmt.Errorf("no edits reach %d %s", target, metric)
+	}
+
+	// the final layer is no longer needed, its buffers hold the layers of a block
+	edits := []Edit{}
+	value := layer[state]
+	blockLayers := make([][]int, blockSize)
+	buffers := [][]int{layer, next}
+	for block := len(checkpoints) - 1; block >= 0; block-- {
+		first := block * blockSize
+		last := min(first+blockSize, len(turns)) - 1
+		blockLayers[0] = checkpoints[block]
+		for i := first; i < last; i++ {
+			j := i - first + 1
+			if blockLayers[j] == nil {
+				if len(buffers) > 0 {
+					blockLayers[j], buffers = buffers[0], buffers[1:]
+				} else {
+					blockLayers[j] = make([]int, size)
+				}
+			}
+			steps.step(blockLayers[j], blockLayers[j-1], turns[i])
+		}
+
+		for i := last; i >= first; i-- {
+			var to int
+			state, to = steps.previous(blockLayers[i-first], turns[i], state, value)
+			value = blockLayers[i-first][state]
+			if to != turns[i] {
+				edits = append(edits, Edit{Index: i, From: turns[i], To: to})
+			}
+		}
+		// the checkpoint is not needed again
+		checkpoints[block] = nil
+	}
+
+	slices.Reverse(edits)
+	return edits, nil
+}
+
+// layerSteps computes the layers of findEdits for a metric, reusing its buffers for every turn.
+type layerSteps struct {
+	metric  Metric
+	modulus int
+	// edited holds the costs of one row of edits for hits, the others full layers for crossings
+	edited                []int
+	mirrored, right, left []int
+	window                []int
+}
+
+func newLayerSteps(metric Metric, modulus int) *layerSteps {
+	return &layerSteps{metric: metric, modulus: modulus, edited: make([]int, modulus)}
+}
+
+// step computes the layer after turn into next.
+func (ls *layerSteps) step(next, layer []int, turn int) {
+	for i := range next {
+		next[i] = unreachable
+	}
+	if ls.metric == ZeroCrossings {
+		ls.stepCrossings(next, layer, turn)
+	} else {
+		ls.stepHits(next, layer, turn)
+	}
+}
+
+// previous returns the state of layer that reaches state of the next layer with value, and the
+// turn that gets there.
+func (ls *layerSteps) previous(layer []int, turn, state, value int) (int, int) {
+	if ls.metric == ZeroCrossings {
+		return previousCrossings(layer, turn, ls.modulus, state, value)
+	}
+	return previousHits(layer, turn, ls.modulus, state, value)
+}
+
+// stepHits computes the layer after turn for zero hits. A hit only depends on the end position, so
+// an edit moves the end to any position for the shortest distance from the end of the turn.
+func (ls *layerSteps) stepHits(next, layer []int, turn int) {
+	modulus := ls.modulus
+	relax := func(count, position, value int) {
+		if position == 0 {
+			count++
+		}
+		if i := count*modulus + position; i < len(next) && value < next[i] {
+			next[i] = value
+		}
+	}
+
+	edited := ls.edited
+	for count := range len(layer) / modulus {
+		reachable := false
+		end := mod(turn, modulus)
+		for _, value := range layer[count*modulus : (count+1)*modulus] {
+			edited[end] = value
+			if value != unreachable {
+				relax(count, end, value)
+				reachable = true
+			}
+			if end++; end == modulus {
+				end = 0
+			}
+		}
+		if !reachable {
+			continue
+		}
+
+		// distances around the dial: two rounds in each direction reach every position
+		last := edited[modulus-1]
+		for range 2 {
+			for i, value := range edited {
+				last = min(value, inc(last))
+				edited[i] = last
+			}
+		}
+		last = edited[0]
+		for range 2 {
+			for i := modulus - 1; i >= 0; i-- {
+				last = min(edited[i], inc(last))
+				edited[i] = last
+			}
+		}
+		for position, value := range edited {
+			if value != unreachable {
+				relax(count, position, addEdit(value))
+			}
+		}
+	}
+}
+
+// previousHits returns the state of layer that reaches state of the next layer with value, and the
+// turn that gets there.
+func previousHits(layer []int, turn, modulus, state, value int) (int, int) {
+	count, position := state/modulus, state%modulus
+	if position == 0 {
+		count--
+	}
+
+	row := count * modulus
+	if from := mod(position-turn, modulus); layer[row+from] == value {
+		return row + from, turn
+	}
+	for from := range modulus {
+		// the shortest change that ends the turn at position, right on a tie like turnTo
+		shift := mod(position-from-turn, modulus)
+		if shift > modulus/2 {
+			shift -= modulus
+		}
+		if shift != 0 && layer[row+from] != unreachable && layer[row+from]+editCost+abs(shift) == value {
+			return row + from, turn + shift
+		}
+	}
+	panic("day1: no previous state")
+}
+
+// stepCrossings computes the layer after turn for zero crossings.
+//
+// A turn t from the state index s = count*modulus+position ends in the state s+t if t >= 0: every
+// time it passes 0 the position wraps and the count grows, which is the next row. Left turns are
+// right turns on the mirrored dial, where position p is -p. So an edit of a right turn reaches
+// every later state u for the cost |u-s-turn|, which spread computes for all states at once.
+func (ls *layerSteps) stepCrossings(next, layer []int, turn int) {
+	modulus := ls.modulus
+	for position := range modulus {
+		// every row moves by the same passes and to the same position
+		shift := countPasses(position, turn, 0, modulus)*modulus + mod(position+turn, modulus) - position
+		for state := position; state < len(layer) && state+shift < len(next); state += modulus {
+			next[state+shift] = min(next[state+shift], layer[state])
+		}
+	}
+
+	if ls.right == nil {
+		ls.mirrored, ls.right, ls.left = make([]int, len(layer)), make([]int, len(layer)), make([]int, len(layer))
+	}
+	ls.spread(ls.right, layer, turn, 0)
+	mirror(ls.mirrored, layer, modulus)
+	ls.spread(ls.left, ls.mirrored, -turn, 1)
+	mirror(ls.mirrored, ls.left, modulus)
+	for state := range next {
+		next[state] = min(next[state], addEdit(ls.right[state]), addEdit(ls.mirrored[state]))
+	}
+}
+
+// spread computes for every state u the cheapest w[s] + |u-s-turn| of all states s <= u-minTurn into result.
+func (ls *layerSteps) spread(result, w []int, turn, minTurn int) {
+	for i := range result {
+		result[i] = unreachable
+	}
+
+	// u-s >= turn: the cost grows by 1 per state from s+turn on
+	first := max(turn, minTurn)
+	for s, value := range w {
+		if value != unreachable && s+first < len(w) {
+			result[s+first] = min(result[s+first], value+first-turn)
+		}
+	}
+	for u := 1; u < len(result); u++ {
+		result[u] = min(result[u], inc(result[u-1]))
+	}
+
+	// minTurn <= u-s < turn: the cost turn-u+s is the minimum of w[s]+s over a window of s
+	if turn > minTurn {
+		window, head := ls.window[:0], 0
+		for u := range result {
+			if s := u - minTurn; s >= 0 && s < len(w) && w[s] != unreachable {
+				for len(window) > head && w[window[len(window)-1]]+window[len(window)-1] >= w[s]+s {
+					window = window[:len(window)-1]
+				}
+				window = append(window, s)
+			}
+			for len(window) > head && window[head] <= u-turn {
+				head++
+			}
+			if len(window) > head {
+				result[u] = min(result[u], w[window[head]]+window[head]+turn-u)
+			}
+		}
+		ls.window = window
+	}
+}
+
+// previousCrossings returns the state of layer that reaches state of the next layer with value, and
+// the turn that gets there.
+func previousCrossings(layer []int, turn, modulus, state, value int) (int, int) {
+	position := state % modulus
+	from := mod(position-turn, modulus)
+	if count := state/modulus - countPasses(from, turn, 0, modulus); count >= 0 && layer[count*modulus+from] == value {
+		return count*modulus + from, turn
+	}
+
+	for source := range state - position + modulus {
+		if layer[source] == unreachable {
+			continue
+		}
+		// a right turn to state, or a right turn to the mirrored state on the mirrored dial
+		if to := state - source; to >= 0 && to != turn && layer[source]+editCost+abs(to-turn) == value {
+			return source, to
+		}
+		if to := mirrorState(source, modulus) - mirrorState(state, modulus); to < 0 && to != turn &&
+			layer[source]+editCost+abs(to-turn) == value {
+			return source, to
+		}
+	}
+	panic("day1: no previous state")
+}
+
+// mirror copies layer into mirrored with every position p moved to -p.
+func mirror(mirrored, layer []int, modulus int) {
+	for row := 0; row < len(layer); row += modulus {
+		mirrored[row] = layer[row]
+		for position := 1; position < modulus; position++ {
+			mirrored[row+modulus-position] = layer[row+position]
+		}
+	}
+}
+
+func mirrorState(state, modulus int) int {
+	return state - state%modulus + mod(-state, modulus)
+}
+
+// inc adds 1 to a cost, keeping unreachable unreachable.
+func inc(value int) int {
+	if value == unreachable {
+		return value
+	}
+	return value + 1
+}
+
+// addEdit adds the cost of an edit, keeping unreachable unreachable.
+func addEdit(value int) int {
+	if value == unreachable {
+		return value
+	}
+	return value + editCost
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// bestEdit returns the single edit that brings metric closest to target, preferring the smallest change of a turn.
+//
+// Changing turn i shifts every later position by the same delta (mod modulus). So instead of
+// re-simulating every candidate, it sweeps the turns backwards and keeps, for every possible
+// delta, the count the remaining turns would contribute: a histogram of the end positions for
+// hits, and the passes of each later turn from its shifted start for crossings. Together with the
+// unchanged counts of the prefix this evaluates all edits in O(len(turns) * modulus).
+func bestEdit(turns []int, modulus, start int, metric Metric, target int) (best Edit, result int, ok bool) {
+	positions := make([]int, len(turns)+1)
+	positions[0] = start
+	hitsBefore := make([]int, len(turns)+1)
+	crossBefore := make([]int, len(turns)+1)
+	for i, turn := range turns {
+		positions[i+1] = ((positions[i]+turn)%modulus + modulus) % modulus
+		hitsBefore[i+1] = hitsBefore[i]
+		if positions[i+1] == 0 {
+			hitsBefore[i+1]++
+		}
+		crossBefore[i+1] = crossBefore[i] + countPasses(positions[i], turn, 0, modulus)
+	}
+
+	// suffix[delta] is the metric of the turns after i if their start positions are shifted by delta.
+	suffix := make([]int, modulus)
+	bestCost := 0
+	consider := func(i, to, value int) {
+		if to == turns[i] {
+			return
+		}
+		cost := abs(to - turns[i])
+		if !ok || abs(value-target) < abs(result-target) || abs(value-target) == abs(result-target) && cost < bestCost {
+			best, result, bestCost, ok = Edit{Index: i, From: turns[i], To: to}, value, cost, true
+		}
+	}
+
+	for i := len(turns) - 1; i >= 0; i-- {
+		if metric == ZeroHits {
+			// the position after turn i now counts as well; shifted by delta it is a hit if it equals -delta
+			suffix[(modulus-positions[i+1])%modulus]++
+			for delta := 1; delta < modulus; delta++ {
+				shift := delta
+				if shift > modulus/2 {
+					shift -= modulus
+				}
+				consider(i, turns[i]+shift, hitsBefore[i]+suffix[delta])
+			}
+			continue
+		}
+
+		for delta := range modulus {
+			residue := ((turns[i]+delta)%modulus + modulus) % modulus
+			need := target - crossBefore[i] - suffix[delta]
+			to, passes := closestTurn(positions[i], turns[i], residue, need, modulus)
+			consider(i, to, crossBefore[i]+passes+suffix[delta])
+		}
+		for delta := range modulus {
+			suffix[delta] += countPasses((positions[i]+delta)%modulus, turns[i], 0, modulus)
+		}
+	}
+	return best, result, ok
+}
+
+// closestTurn returns a turn from position that is congruent to residue and passes 0 as close to
+// need times as possible, preferring the turn closest to from. Adding a full rotation in the same
+// direction passes 0 once more, so every count from the shortest turn in each direction upwards is reachable.
+func closestTurn(position, from, residue, need, modulus int) (turn, passes int) {
+	bestTurn, bestPasses := 0, -1
+	for _, base := range []int{residue, residue - modulus} {
+		step := modulus
+		if base < 0 {
+			step = -modulus
+		}
+		basePasses := countPasses(position, base, 0, modulus)
+		candidate, candidatePasses := base, basePasses
+		if need > basePasses {
+			candidate += (need - basePasses) * step
+			candidatePasses = need
+		}
+		if candidate == from {
+			// not an edit, one more rotation is the closest that is
+			candidate += step
+			candidatePasses++
+		}
+
+		if bestPasses < 0 || abs(candidatePasses-need) < abs(bestPasses-need) ||
+			abs(candidatePasses-need) == abs(bestPasses-need) && abs(candidate-from) < abs(bestTurn-from) {
+			bestTurn, bestPasses = candidate, candidatePasses
+		}
+	}
+	return bestTurn, bestPasses
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,298 @@
+package day1
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func applyEdits(turns []int, edits []Edit) []int {
+	turns = slices.Clone(turns)
+	for _, e := range edits {
+		turns[e.Index] = e.To
+	}
+	return turns
+}
+
+// totalChange returns how much the edits change the turns in total.
+func totalChange(edits []Edit) int {
+	change := 0
+	for _, e := range edits {
+		change += abs(e.To - e.From)
+	}
+	return change
+}
+
+func TestFindEditsExample(t *testing.T) {
+	moves, err := ParseMoves(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	turns, err := ResolveTurns(moves)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		metric     Metric
+		target     int
+		wantEdits  int
+		wantChange int
+	}{
+		{metric: ZeroHits, target: 3, wantEdits: 0},
+		{metric: ZeroHits, target: 4, wantEdits: 1, wantChange: 14},
+		{metric: ZeroHits, target: 0, wantEdits: 1, wantChange: 1},
+		{metric: ZeroHits, target: 6, wantEdits: 4, wantChange: 34},
+		{metric: ZeroHits, target: 10, wantEdits: 10, wantChange: 220},
+		{metric: ZeroCrossings, target: 6, wantEdits: 0},
+		{metric: ZeroCrossings, target: 5, wantEdits: 1, wantChange: 1},
+		{metric: ZeroCrossings, target: 16, wantEdits: 1, wantChange: 887},
+		{metric: ZeroCrossings, target: 0, wantEdits: 3, wantChange: 179},
+	} {
+		edits, err := FindEdits(turns, tt.metric, tt.target)
+		if err != nil {
+			t.Errorf("%v = %d: %v", tt.metric, tt.target, err)
+			continue
+		}
+		if got := countMetric(applyEdits(turns, edits), maxCount, startPosition, tt.metric); got != tt.target {
+			t.Errorf("%v = %d: edits %v give %d", tt.metric, tt.target, edits, got)
+		}
+		if len(edits) != tt.wantEdits || totalChange(edits) != tt.wantChange {
+			t.Errorf("%v = %d: got %d edits %v changing the turns by %d, want %d edits changing them by %d",
+				tt.metric, tt.target, len(edits), edits, totalChange(edits), tt.wantEdits, tt.wantChange)
+		}
+	}
+
+	if _, err := FindEdits(turns, ZeroHits, len(turns)+1); err == nil {
+		t.Errorf("expected an error for more hits than turns")
+	}
+}
+
+// exhaustiveEdits tries every replacement of every subset of turns by the candidates and returns
+// the fewest edits and their smallest total change for every count up to limit.
+func exhaustiveEdits(turns []int, modulus, start int, metric Metric, limit int, candidates func(turn int) []int) map[int][2]int {
+	best := map[int][2]int{}
+	var try func(i, position, count, edits, change int)
+	try = func(i, position, count, edits, change int) {
+		if count > limit {
+			return
+		}
+		if i == len(turns) {
+			if b, ok := best[count]; !ok || edits < b[0] || edits == b[0] && change < b[1] {
+				best[count] = [2]int{edits, change}
+			}
+			return
+		}
+		next := func(turn int) (int, int) {
+			end := mod(position+turn, modulus)
+			if metric == ZeroCrossings {
+				return end, count + countPasses(position, turn, 0, modulus)
+			}
+			if end == 0 {
+				return end, count + 1
+			}
+			return end, count
+		}
+
+		end, c := next(turns[i])
+		try(i+1, end, c, edits, change)
+		for _, to := range candidates(turns[i]) {
+			if to != turns[i] {
+				end, c := next(to)
+				try(i+1, end, c, edits+1, change+abs(to-turns[i]))
+			}
+		}
+	}
+	try(0, start, 0, 0, 0)
+	return best
+}
+
+// TestFindEditsMinimal compares the edits with trying every set of edits on small dials.
+func TestFindEditsMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(25))
+	check := func(turns []int, modulus, start int, metric Metric, target int, want [2]int, reachable bool) {
+		t.Helper()
+		edits, err := findEdits(turns, modulus, start, metric, target)
+		if !reachable {
+			if err == nil {
+				t.Fatalf("%v %v from %d, target %d: got %v, want an error", turns, metric, start, target, edits)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("%v %v from %d, target %d: %v", turns, metric, start, target, err)
+		}
+		if got := countMetric(applyEdits(turns, edits), modulus, start, metric); got != target {
+			t.Fatalf("%v %v from %d, target %d: edits %v give %d", turns, metric, start, target, edits, got)
+		}
+		if len(edits) != want[0] || totalChange(edits) != want[1] {
+			t.Fatalf("%v %v from %d, target %d: got %d edits %v changing the turns by %d, want %d edits changing them by %d",
+				turns, metric, start, target, len(edits), edits, totalChange(edits), want[0], want[1])
+		}
+	}
+
+	// the counterexamples to closing the difference one edit at a time
+	for _, tt := range []struct {
+		turns  []int
+		start  int
+		target int
+		want   [2]int
+	}{
+		{turns: []int{-15, -16, 17, 20}, start: 6, target: 3, want: [2]int{2, 2}},
+		{turns: []int{10, -4, 15, -8, -19, 20}, start: 7, target: 5, want: [2]int{4, 7}},
+	} {
+		best := exhaustiveEdits(tt.turns, 10, tt.start, ZeroHits, len(tt.turns), func(turn int) []int { return nearTurns(turn, 10) })
+		if best[tt.target] != tt.want {
+			t.Fatalf("%v from %d, target %d: exhaustive search found %v, want %v", tt.turns, tt.start, tt.target, best[tt.target], tt.want)
+		}
+		check(tt.turns, 10, tt.start, ZeroHits, tt.target, tt.want, true)
+	}
+
+	// a hit only depends on the end of a turn, so turns further than a rotation away are never cheaper
+	const hitModulus = 6
+	for range 60 {
+		turns := make([]int, 1+rng.Intn(5))
+		for i := range turns {
+			turns[i] = rng.Intn(31) - 15
+		}
+		start := rng.Intn(hitModulus)
+
+		best := exhaustiveEdits(turns, hitModulus, start, ZeroHits, len(turns), func(turn int) []int { return nearTurns(turn, hitModulus) })
+		for target := 0; target <= len(turns)+1; target++ {
+			want, ok := best[target]
+			check(turns, hitModulus, start, ZeroHits, target, want, ok)
+		}
+	}
+
+	// a turn passes 0 at least |turn|/modulus times, so longer turns overshoot every target
+	const crossModulus, crossLimit = 4, 7
+	allTurns := []int{}
+	for to := -crossModulus * (crossLimit + 1); to <= crossModulus*(crossLimit+1); to++ {
+		allTurns = append(allTurns, to)
+	}
+	for range 40 {
+		turns := make([]int, 1+rng.Intn(3))
+		for i := range turns {
+			turns[i] = rng.Intn(21) - 10
+		}
+		start := rng.Intn(crossModulus)
+
+		best := exhaustiveEdits(turns, crossModulus, start, ZeroCrossings, crossLimit, func(int) []int { return allTurns })
+		for target := 0; target <= crossLimit; target++ {
+			want, ok := best[target]
+			check(turns, crossModulus, start, ZeroCrossings, target, want, ok)
+		}
+	}
+}
+
+// nearTurns returns the turns at most one rotation away from turn.
+func nearTurns(turn, modulus int) []int {
+	turns := []int{}
+	for to := turn - modulus; to <= turn+modulus; to++ {
+		turns = append(turns, to)
+	}
+	return turns
+}
+
+// TestFindEditsSingle compares single edits with trying every replacement turn on a small dial.
+func TestFindEditsSingle(t *testing.T) {
+	const modulus = 10
+	rng := rand.New(rand.NewSource(13))
+
+	for range 300 {
+		turns := make([]int, 1+rng.Intn(6))
+		for i := range turns {
+			turns[i] = rng.Intn(51) - 25
+		}
+		start := rng.Intn(modulus)
+
+		for _, metric := range []Metric{ZeroHits, ZeroCrossings} {
+			current := countMetric(turns, modulus, start, metric)
+			// cheapest single edit per reachable count
+			cheapest := map[int]int{}
+			for i := range turns {
+				// a turn passing 0 more than 2*modulus times is never needed for the targets below
+				for to := -25 * modulus; to <= 25*modulus; to++ {
+					if to == turns[i] {
+						continue
+					}
+					edited := slices.Clone(turns)
+					edited[i] = to
+					value := countMetric(edited, modulus, start, metric)
+					if cost, ok := cheapest[value]; !ok || abs(to-turns[i]) < cost {
+						cheapest[value] = abs(to - turns[i])
+					}
+				}
+			}
+
+			for target, cost := range cheapest {
+				if target == current || target > 2*modulus {
+					continue
+				}
+				edits, err := findEdits(turns, modulus, start, metric, target)
+				if err != nil {
+					t.Fatalf("%v %v from %d, target %d: %v", turns, metric, start, target, err)
+				}
+				if len(edits) != 1 || abs(edits[0].To-edits[0].From) != cost {
+					t.Fatalf("%v %v from %d, target %d: got %v, want a single edit changing a turn by %d", turns, metric, start, target, edits, cost)
+				}
+				if got := countMetric(applyEdits(turns, edits), modulus, start, metric); got != target {
+					t.Fatalf("%v %v from %d, target %d: edits %v give %d", turns, metric, start, target, edits, got)
+				}
+			}
+		}
+	}
+}
+
+func TestFindEditsReachesEveryHitCount(t *testing.T) {
+	const modulus = 10
+	rng := rand.New(rand.NewSource(7))
+
+	for range 100 {
+		turns := make([]int, 1+rng.Intn(8))
+		for i := range turns {
+			turns[i] = rng.Intn(41) - 20
+		}
+		start := rng.Intn(modulus)
+
+		for target := 0; target <= len(turns); target++ {
+			edits, err := findEdits(turns, modulus, start, ZeroHits, target)
+			if err != nil {
+				t.Fatalf("%v from %d, target %d: %v", turns, start, target, err)
+			}
+			if got := countMetric(applyEdits(turns, edits), modulus, start, ZeroHits); got != target {
+				t.Fatalf("%v from %d, target %d: edits %v give %d", turns, start, target, edits, got)
+			}
+		}
+	}
+}
+
+// BenchmarkFindEdits runs FindEdits on as many turns as a puzzle input has. The dynamic program
+// grows with the target, a single edit does not.
+func BenchmarkFindEdits(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	turns := make([]int, 4500)
+	for i := range turns {
+		turns[i] = rng.Intn(1999) - 999
+	}
+	crossings := countMetric(turns, maxCount, startPosition, ZeroCrossings)
+
+	for _, bm := range []struct {
+		name   string
+		metric Metric
+		target int
+	}{
+		{"hits/200", ZeroHits, 200},
+		{"crossings/single", ZeroCrossings, crossings + 1000},
+		{"crossings/100", ZeroCrossings, 100},
+		{"crossings/1000", ZeroCrossings, 1000},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := FindEdits(turns, bm.metric, bm.target); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
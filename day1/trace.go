@@ -0,0 +1,99 @@
+package day1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TraceStep describes a single turn of the standard dial.
+type TraceStep struct {
+	Index       int    `json:"index"`
+	Instruction string `json:"instruction"`
+	Start       int    `json:"start"`
+	End         int    `json:"end"`
+	ZeroPasses  int    `json:"zero_passes"`
+	Hit         bool   `json:"hit"`
+	TotalHits   int    `json:"total_hits"`
+	TotalPasses int    `json:"total_passes"`
+}
+
+// Tracer turns the standard dial and records every step.
+type Tracer struct {
+	dial  *Dial
+	steps int
+}
+
+func NewTracer() *Tracer {
+	return &Tracer{dial: newStandardDial()}
+}
+
+// Apply moves the dial and returns the step. It returns an error without moving the dial if the
+// move sets a position the dial does not have.
+func (t *Tracer) Apply(m Move) (TraceStep, error) {
+	step := TraceStep{
+		Index:       t.steps + 1,
+		Instruction: m.String(),
+		Start:       t.dial.Position,
+		ZeroPasses:  -t.dial.Crossings[0],
+	}
+
+	hits := t.dial.Hits[0]
+	if err := m.apply(t.dial); err != nil {
+		return TraceStep{}, err
+	}
+	t.steps++
+
+	step.End = t.dial.Position
+	step.ZeroPasses += t.dial.Crossings[0]
+	step.Hit = t.dial.Hits[0] > hits
+	step.TotalHits = t.dial.Hits[0]
+	step.TotalPasses = t.dial.Crossings[0]
+	return step, nil
+}
+
+// Trace returns the steps of executing all moves on the standard dial.
+func Trace(moves []Move) ([]TraceStep, error) {
+	t := NewTracer()
+	steps := make([]TraceStep, len(moves))
+	for i, m := range moves {
+		step, err := t.Apply(m)
+		if err != nil {
+			return nil, fmt.Errorf("move %d (%v): %w", i+1, m, err)
+		}
+		steps[i] = step
+	}
+	return steps, nil
+}
+
+// TraceWriter writes trace steps as text or as JSON, one object per line.
+type TraceWriter struct {
+	w      io.Writer
+	format string
+}
+
+func NewTraceWriter(w io.Writer, format string) (*TraceWriter, error) {
+	if format != "text" && format != "json" {
+		return nil, fmt.Errorf("unknown trace format '%s', expected text or json", format)
+	}
+	return &TraceWriter{w: w, format: format}, nil
+}
+
+func (tw *TraceWriter) Write(step TraceStep) error {
+	if tw.format == "json" {
+		data, err := json.Marshal(step)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(tw.w, "%s\n", data)
+		return err
+	}
+
+	landed := ""
+	if step.Hit {
+		landed = " (landed on 0)"
+	}
+	_, err := fmt.Fprintf(tw.w, "%6d  %-6s %2d -> %2d  zero passes: %d%s - total hits: %d, total passes: %d\n",
+		step.Index, step.Instruction, step.Start, step.End, step.ZeroPasses, landed, step.TotalHits, step.TotalPasses)
+	return err
+}
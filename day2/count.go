@@ -0,0 +1,149 @@
+package day2
+
+import "math/big"
+
+// An invalid ID of n digits made of a d digit pattern is pattern * (radix^(n-d) + ... + radix^d + 1).
+// So instead of checking every ID of a range, the invalid IDs are counted and summed per digit
+// length and pattern length as an arithmetic progression of the patterns.
+
+var one = big.NewInt(1)
+
+// rangeCounter is implemented by rules whose invalid IDs can be counted without checking every ID.
+type rangeCounter interface {
+	countRange(r Range, radix int) (count, sum *big.Int)
+}
+
+func (rule Repeats) countRange(r Range, radix int) (count, sum *big.Int) {
+	count, sum = new(big.Int), new(big.Int)
+	minDigits, maxDigits := digitRange(r, radix)
+	for n := minDigits; n <= maxDigits; n++ {
+		if n%rule.Times == 0 {
+			c, s := countPattern(r, radix, n, n/rule.Times)
+			count.Add(count, c)
+			sum.Add(sum, s)
+		}
+	}
+	return count, sum
+}
+
+// countRange sums the IDs whose shortest repeating block fits at least Times times into the ID.
+// Every ID has exactly one shortest block, so nothing is counted twice.
+func (rule RepeatsAtLeast) countRange(r Range, radix int) (count, sum *big.Int) {
+	count, sum = new(big.Int), new(big.Int)
+	minDigits, maxDigits := digitRange(r, radix)
+	for n := minDigits; n <= maxDigits; n++ {
+		for blockLength := 1; blockLength <= n/rule.Times; blockLength++ {
+			if n%blockLength == 0 {
+				c, s := countShortestBlock(r, radix, n, blockLength)
+				count.Add(count, c)
+				sum.Add(sum, s)
+			}
+		}
+	}
+	return count, sum
+}
+
+// countShortestBlock returns the count and sum of the n digit IDs in r whose shortest repeating block has length d.
+//
+// An ID repeating a block of length e also repeats every block whose length is a multiple of e and
+// divides n. So the IDs repeating a block of length d are the union of the IDs whose shortest block
+// length e divides d, and Möbius inversion over the divisors of d extracts those with e = d.
+func countShortestBlock(r Range, radix, n, d int) (count, sum *big.Int) {
+	count, sum = new(big.Int), new(big.Int)
+	for e := 1; e <= d; e++ {
+		if d%e != 0 {
+			continue
+		}
+		switch mobius(d / e) {
+		case 1:
+			c, s := countPattern(r, radix, n, e)
+			count.Add(count, c)
+			sum.Add(sum, s)
+		case -1:
+			c, s := countPattern(r, radix, n, e)
+			count.Sub(count, c)
+			sum.Sub(sum, s)
+		}
+	}
+	return count, sum
+}
+
+// mobius returns the Möbius function of n: 0 if a square divides n, otherwise -1 to the number of prime factors.
+func mobius(n int) int {
+	mu := 1
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			n /= p
+			if n%p == 0 {
+				return 0
+			}
+			mu = -mu
+		}
+	}
+	if n > 1 {
+		mu = -mu
+	}
+	return mu
+}
+
+// digitRange returns the smallest and largest number of digits of the positive IDs in r.
+func digitRange(r Range, radix int) (minDigits, maxDigits int) {
+	if r.End.Sign() <= 0 || r.Start.Cmp(r.End) > 0 {
+		return 1, 0
+	}
+	minDigits = 1
+	if r.Start.Sign() > 0 {
+		minDigits = len(r.Start.Text(radix))
+	}
+	return minDigits, len(r.End.Text(radix))
+}
+
+// countPattern returns the count and sum of the n digit IDs in r that repeat a d digit pattern,
+// for d = n that are all n digit IDs.
+func countPattern(r Range, radix, n, d int) (count, sum *big.Int) {
+	b := big.NewInt(int64(radix))
+	lo := maxInt(r.Start, pow(b, n-1))
+	hi := minInt(r.End, new(big.Int).Sub(pow(b, n), one))
+
+	// multiplier is (radix^n - 1) / (radix^d - 1)
+	multiplier := new(big.Int).Sub(pow(b, n), one)
+	multiplier.Quo(multiplier, new(big.Int).Sub(pow(b, d), one))
+
+	// patterns are the d digit numbers whose multiple lies in [lo, hi]
+	first, rem := new(big.Int).QuoRem(lo, multiplier, new(big.Int))
+	if rem.Sign() != 0 {
+		first.Add(first, one)
+	}
+	first = maxInt(first, pow(b, d-1))
+	last := minInt(new(big.Int).Quo(hi, multiplier), new(big.Int).Sub(pow(b, d), one))
+	if first.Cmp(last) > 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	count = new(big.Int).Sub(last, first)
+	count.Add(count, one)
+	// sum of first..last times the multiplier
+	sum = new(big.Int).Add(first, last)
+	sum.Mul(sum, count)
+	sum.Rsh(sum, 1)
+	sum.Mul(sum, multiplier)
+	return count, sum
+}
+
+func pow(b *big.Int, n int) *big.Int {
+	return new(big.Int).Exp(b, big.NewInt(int64(n)), nil)
+}
+
+func maxInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,137 @@
+package day2
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+// Range is an inclusive range of IDs.
+type Range struct {
+	Start *big.Int
+	End   *big.Int
+}
+
+// NewRange returns the range start..end.
+func NewRange(start, end int64) Range {
+	return Range{Start: big.NewInt(start), End: big.NewInt(end)}
+}
+
+func (r Range) String() string {
+	return r.Start.String() + "-" + r.End.String()
+}
+
+// ParseRanges parses a single line of comma separated "start-end" ranges of numbers in the given radix (2 to 36).
+func ParseRanges(rawRanges string, radix int) ([]Range, error) {
+	if radix < 2 || radix > 36 {
+		return nil, fmt.Errorf("invalid radix %d, expected 2 to 36", radix)
+	}
+
+	// errors are reported at their position in the untrimmed input
+	trimmed := strings.TrimLeftFunc(rawRanges, unicode.IsSpace)
+	skipped := rawRanges[:len(rawRanges)-len(trimmed)]
+	line := 1 + strings.Count(skipped, "\n")
+	// the characters skipped on the first line, plus one
+	column := len(skipped) - strings.LastIndex(skipped, "\n")
+	return parseRanges(strings.TrimRightFunc(trimmed, unicode.IsSpace), radix, line, column)
+}
+
+// parseRanges parses a single line of comma separated "start-end" ranges that starts at line and column.
+func parseRanges(rawRanges string, radix, line, column int) (ranges []Range, err error) {
+	rangesStrs := strings.Split(rawRanges, ",")
+	ranges = make([]Range, len(rangesStrs))
+
+	// column is the column of the current range, used for error reporting
+	for i, rStr := range rangesStrs {
+		parts := strings.Split(rStr, "-")
+		if len(parts) != 2 {
+			return nil, input.Errorf(line, column, "invalid range: '%s'", rStr)
+		}
+		var ok bool
+		ranges[i].Start, ok = new(big.Int).SetString(parts[0], radix)
+		if !ok {
+			return nil, input.Errorf(line, column, "invalid start in range '%s': not a base %d number", rStr, radix)
+		}
+		ranges[i].End, ok = new(big.Int).SetString(parts[1], radix)
+		if !ok {
+			return nil, input.Errorf(line, column+len(parts[0])+1, "invalid end in range '%s': not a base %d number", rStr, radix)
+		}
+		if ranges[i].Start.Cmp(ranges[i].End) > 0 {
+			return nil, input.Errorf(line, column, "invalid range '%s': start is greater than end", rStr)
+		}
+		column += len(rStr) + 1
+	}
+	return ranges, nil
+}
+
+// The puzzle's rules: part one looks for IDs made of a block repeated twice, part two for blocks repeated at least twice.
+var (
+	PartOneRule IDRule = Repeats{2}
+	PartTwoRule IDRule = RepeatsAtLeast{2}
+)
+
+// filterRange returns the IDs of r that the rule matches by checking every ID.
+func filterRange(r Range, radix int, rule IDRule) (wrongIds []*big.Int, sum *big.Int) {
+	sum = new(big.Int)
+	for id := new(big.Int).Set(maxInt(r.Start, one)); id.Cmp(r.End) <= 0; id.Add(id, one) {
+		if rule.Invalid(id.Text(radix)) {
+			wrongIds = append(wrongIds, new(big.Int).Set(id))
+			sum.Add(sum, id)
+		}
+	}
+	return wrongIds, sum
+}
+
+// countRange returns the count and sum of the IDs of r that the rule matches, without checking
+// every ID if the rule supports it.
+func countRange(r Range, radix int, rule IDRule) (count, sum *big.Int) {
+	if c, ok := rule.(rangeCounter); ok {
+		return c.countRange(r, radix)
+	}
+	wrongIds, sum := filterRange(r, radix, rule)
+	return big.NewInt(int64(len(wrongIds))), sum
+}
+
+// Sum returns the sum of the IDs of all ranges that the rule matches.
+func Sum(ranges []Range, radix int, rule IDRule) *big.Int {
+	sum := new(big.Int)
+	for _, r := range ranges {
+		_, sumRange := countRange(r, radix, rule)
+		sum.Add(sum, sumRange)
+	}
+	return sum
+}
+
+// toInt converts a sum to an answer, which fails if it does not fit into an int.
+func toInt(sum *big.Int) (int, error) {
+	if !sum.IsInt64() || int64(int(sum.Int64())) != sum.Int64() {
+		return 0, fmt.Errorf("sum %s does not fit into an int", sum)
+	}
+	return int(sum.Int64()), nil
+}
+
+func init() {
+	solver.Register(2, func() solver.Solver { return &Solver{} })
+}
+
+// Solver sums the invalid IDs of all ranges, using the half-repeat rule (part one) and the any-repeat rule (part two).
+type Solver struct {
+	ranges []Range
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.ranges, err = ParseRanges(input, 10)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return toInt(Sum(s.ranges, 10, PartOneRule))
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return toInt(Sum(s.ranges, 10, PartTwoRule))
+}
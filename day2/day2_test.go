@@ -0,0 +1,271 @@
+package day2
+
+import (
+	"math/big"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = "11-22,95-115,998-1012,1188511880-1188511890,222220-222224," +
+	"1698522-1698528,446443-446449,38593856-38593862,565653-565659," +
+	"824824821-824824827,2121212118-2121212124"
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 2, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 1227775554, PartTwo: 4174379265},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 1227775554, PartTwo: 4174379265},
+		{Name: "single digits", Input: "1-9", PartOne: 0, PartTwo: 0},
+		{Name: "single id", Input: "1010-1010", PartOne: 1010, PartTwo: 1010},
+		{Name: "odd length", Input: "100-999", PartOne: 0, PartTwo: 111 + 222 + 333 + 444 + 555 + 666 + 777 + 888 + 999},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 2, []solvertest.ErrorCase{
+		{Name: "missing dash", Input: "11-22,95", Line: 1, Column: 7},
+		{Name: "invalid start", Input: "11-22,x-5", Line: 1, Column: 7},
+		{Name: "invalid end", Input: "11-22,95-1x5", Line: 1, Column: 10},
+		{Name: "start after end", Input: "11-22,95-90", Line: 1, Column: 7},
+		{Name: "after leading spaces", Input: "  11-22,x-5", Line: 1, Column: 9},
+		{Name: "after a leading newline", Input: "\n11-x", Line: 2, Column: 4},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 2)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 2, example)
+}
+
+func TestFilterRange(t *testing.T) {
+	tests := []struct {
+		r       Range
+		partOne int64
+		partTwo int64
+	}{
+		{r: NewRange(11, 22), partOne: 33, partTwo: 33},
+		{r: NewRange(95, 115), partOne: 99, partTwo: 99 + 111},
+		{r: NewRange(998, 1012), partOne: 1010, partTwo: 999 + 1010},
+		{r: NewRange(565653, 565659), partOne: 0, partTwo: 565656},
+		{r: NewRange(2121212118, 2121212124), partOne: 0, partTwo: 2121212121},
+	}
+
+	for _, tt := range tests {
+		for _, rule := range []struct {
+			rule IDRule
+			want int64
+		}{{PartOneRule, tt.partOne}, {PartTwoRule, tt.partTwo}} {
+			if _, got := filterRange(tt.r, 10, rule.rule); got.Int64() != rule.want {
+				t.Errorf("filterRange(%v, %v) = %d, want %d", tt.r, rule.rule, got, rule.want)
+			}
+			if _, got := countRange(tt.r, 10, rule.rule); got.Int64() != rule.want {
+				t.Errorf("countRange(%v, %v) = %d, want %d", tt.r, rule.rule, got, rule.want)
+			}
+		}
+	}
+}
+
+// TestCountMatchesFilter compares the closed forms with checking every ID, on ranges of all magnitudes and radixes.
+func TestCountMatchesFilter(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	type radixRange struct {
+		r     Range
+		radix int
+	}
+	ranges := []radixRange{
+		{NewRange(1, 100000), 10}, {NewRange(0, 0), 10}, {NewRange(5, 4), 10}, {NewRange(-20, 20), 10},
+		{NewRange(999990, 1000010), 10}, {NewRange(9999999000, 10000001000), 10},
+		{NewRange(1, 5000), 2}, {NewRange(1, 5000), 16}, {NewRange(1, 5000), 36},
+	}
+	for range 200 {
+		radix := 2 + rng.Intn(35)
+		start := new(big.Int).Rand(rng, new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(1+rng.Intn(8))), nil))
+		ranges = append(ranges, radixRange{Range{start, new(big.Int).Add(start, big.NewInt(rng.Int63n(5000)))}, radix})
+	}
+	// beyond int64
+	start, _ := new(big.Int).SetString("123456789012345678901234567890123456780", 10)
+	ranges = append(ranges, radixRange{Range{start, new(big.Int).Add(start, big.NewInt(20000))}, 10})
+
+	rules := []rangeCounter{Repeats{2}, Repeats{3}, Repeats{4}, RepeatsAtLeast{2}, RepeatsAtLeast{3}}
+	for _, tt := range ranges {
+		for _, rule := range rules {
+			wrongIds, sum := filterRange(tt.r, tt.radix, rule.(IDRule))
+			if count, got := rule.countRange(tt.r, tt.radix); count.Int64() != int64(len(wrongIds)) || got.Cmp(sum) != 0 {
+				t.Errorf("%v on %v, base %d = %d, %d, want %d, %d", rule, tt.r, tt.radix, count, got, len(wrongIds), sum)
+			}
+		}
+	}
+}
+
+func TestCountLargeRange(t *testing.T) {
+	// every pattern of 1 to 9 digits, repeated twice
+	r := Range{big.NewInt(1), new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil), one)}
+	if count, _ := countRange(r, 10, PartOneRule); count.Int64() != 999999999 {
+		t.Errorf("part one: got %d IDs below 10^18, want 999999999", count)
+	}
+
+	// 1 to 2^64 - 1 in binary: every bit pattern of 1 to 32 bits starting with 1, repeated twice
+	r = Range{big.NewInt(1), new(big.Int).Sub(new(big.Int).Lsh(one, 64), one)}
+	if count, _ := countRange(r, 2, PartOneRule); count.Int64() != 1<<32-1 {
+		t.Errorf("part one in binary: got %d IDs below 2^64, want %d", count, 1<<32-1)
+	}
+
+	ones, _ := new(big.Int).SetString(strings.Repeat("1", 40), 10)
+	if count, sum := countRange(Range{ones, ones}, 10, PartTwoRule); count.Int64() != 1 || sum.Cmp(ones) != 0 {
+		t.Errorf("part two on 40 ones = %d, %d, want 1, %d", count, sum, ones)
+	}
+}
+
+func TestParseRangesRadix(t *testing.T) {
+	ranges, err := ParseRanges("a-1F,101-110\n", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 2 || ranges[0].Start.Int64() != 10 || ranges[0].End.Int64() != 31 || ranges[1].Start.Int64() != 257 {
+		t.Errorf("ParseRanges in hex: got %v", ranges)
+	}
+	// of the hex patterns repeated twice only 0x11 lies in 0xa..0x1f
+	if sum := Sum(ranges[:1], 16, PartOneRule); sum.Int64() != 0x11 {
+		t.Errorf("part one in hex = %d, want %d", sum, 0x11)
+	}
+
+	if _, err := ParseRanges("1-2", 37); err == nil {
+		t.Errorf("expected an error for radix 37")
+	}
+	if _, err := ParseRanges("1-2", 2); err == nil {
+		t.Errorf("expected an error for a binary range containing 2")
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	s := &Solver{}
+	if err := s.Parse("1-99999999999999999999"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PartOne(); err == nil {
+		t.Errorf("expected an error for a sum that does not fit into an int")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	ranges, err := ParseRanges("50-60,1-10,11-12,55-70,8-9,100-100,200-210,211-220", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := Normalize(ranges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		r           string
+		inputs      []int
+		overlapping []int
+	}{
+		{r: "1-12", inputs: []int{1, 2, 4}, overlapping: []int{1, 4}},
+		{r: "50-70", inputs: []int{0, 3}, overlapping: []int{0, 3}},
+		{r: "100-100", inputs: []int{5}},
+		{r: "200-220", inputs: []int{6, 7}},
+	}
+	if len(merged) != len(want) {
+		t.Fatalf("got %d merged ranges %v, want %d", len(merged), merged, len(want))
+	}
+	for i, w := range want {
+		if merged[i].String() != w.r || !slices.Equal(merged[i].Inputs, w.inputs) || !slices.Equal(merged[i].Overlapping, w.overlapping) {
+			t.Errorf("merged range %d: got %v from %v overlapping %v, want %s from %v overlapping %v",
+				i, merged[i].Range, merged[i].Inputs, merged[i].Overlapping, w.r, w.inputs, w.overlapping)
+		}
+	}
+
+	if _, err := Normalize([]Range{NewRange(1, 2), NewRange(5, 4)}); err == nil {
+		t.Errorf("expected an error for a range that starts after its end")
+	}
+}
+
+func TestSemantics(t *testing.T) {
+	ranges, err := ParseRanges("10-30,20-40,22-22", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		semantics Semantics
+		want      int64
+	}{
+		{semantics: PerRange, want: (11 + 22) + (22 + 33) + 22},
+		{semantics: Union, want: 11 + 22 + 33},
+	} {
+		summed, merged, err := tt.semantics.Apply(ranges)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merged) != 1 || !slices.Equal(merged[0].Overlapping, []int{0, 1, 2}) {
+			t.Errorf("%v: got merges %v, want all three ranges overlapping", tt.semantics, merged)
+		}
+		if got := Sum(summed, 10, PartOneRule); got.Int64() != tt.want {
+			t.Errorf("%v: got %d, want %d", tt.semantics, got, tt.want)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule    string
+		invalid []string
+		valid   []string
+	}{
+		{rule: "repeats(3)", invalid: []string{"111", "121212", "111111"}, valid: []string{"11", "1212", "12121"}},
+		{rule: "repeats-at-least(3)", invalid: []string{"111", "1111", "121212"}, valid: []string{"1212", "123123"}},
+		{rule: "palindrome", invalid: []string{"7", "121", "1221"}, valid: []string{"12", "1231"}},
+		{rule: "digitsum(3)", invalid: []string{"3", "111", "ff"}, valid: []string{"1", "1a"}},
+		{rule: "and(repeats(2), palindrome)", invalid: []string{"11", "1111"}, valid: []string{"1212", "121"}},
+		{rule: " or( repeats(2) ,palindrome )", invalid: []string{"1212", "121"}, valid: []string{"12"}},
+		{rule: "not(or(repeats(2), palindrome))", invalid: []string{"12"}, valid: []string{"1212", "121"}},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Errorf("ParseRule(%q): %v", tt.rule, err)
+			continue
+		}
+		for _, digits := range tt.invalid {
+			if !rule.Invalid(digits) {
+				t.Errorf("%v: expected %s to be invalid", rule, digits)
+			}
+		}
+		for _, digits := range tt.valid {
+			if rule.Invalid(digits) {
+				t.Errorf("%v: expected %s to be valid", rule, digits)
+			}
+		}
+
+		if again, err := ParseRule(rule.String()); err != nil || again.String() != rule.String() {
+			t.Errorf("ParseRule(%q) does not round trip: %v, %v", rule, again, err)
+		}
+	}
+
+	for _, invalid := range []string{"", "repeats", "repeats(1)", "digitsum(0)", "and()", "not(palindrome, palindrome)", "palindrome)", "or(palindrome", "prime"} {
+		if _, err := ParseRule(invalid); err == nil {
+			t.Errorf("ParseRule(%q): expected an error", invalid)
+		}
+	}
+}
+
+func TestSumWithoutClosedForm(t *testing.T) {
+	ranges, err := ParseRanges("1-200", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1-9, 11, 22, ..., 99 and 101, 111, ..., 191
+	want := int64(45 + 11*45 + 10*101 + 10*45)
+	if got := Sum(ranges, 10, Palindrome{}); got.Int64() != want {
+		t.Errorf("palindromes in 1-200: got %d, want %d", got, want)
+	}
+}
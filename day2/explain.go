@@ -0,0 +1,110 @@
+package day2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Explanation tells why an ID is invalid: the rule that matched and, for the repeat rules, the
+// block that is repeated Times times.
+type Explanation struct {
+	// Range is the 1 based index of the range containing the ID.
+	Range int    `json:"range"`
+	ID    string `json:"id"`
+	Rule  string `json:"rule"`
+	Block string `json:"block,omitempty"`
+	Times int    `json:"times,omitempty"`
+}
+
+// Explain returns why the ID with the given digits is invalid under rule, ok is false if it is valid.
+func Explain(rule IDRule, digits string) (e Explanation, ok bool) {
+	if !rule.Invalid(digits) {
+		return Explanation{}, false
+	}
+	e = Explanation{ID: digits, Rule: rule.String()}
+
+	switch r := rule.(type) {
+	case Repeats:
+		e.Block, e.Times = digits[:len(digits)/r.Times], r.Times
+	case RepeatsAtLeast:
+		// the shortest block gives the most repetitions
+		for blockLength := 1; blockLength <= len(digits)/r.Times; blockLength++ {
+			if len(digits)%blockLength == 0 && isRepetition(digits, blockLength) {
+				e.Block, e.Times = digits[:blockLength], len(digits)/blockLength
+				break
+			}
+		}
+	case or:
+		// the first matching rule is the reason
+		for _, sub := range r {
+			if subExplanation, ok := Explain(sub, digits); ok {
+				return subExplanation, true
+			}
+		}
+	case and:
+		// all rules match, take the pattern of the first one that has one
+		for _, sub := range r {
+			if subExplanation, _ := Explain(sub, digits); subExplanation.Block != "" {
+				e.Block, e.Times = subExplanation.Block, subExplanation.Times
+				break
+			}
+		}
+	}
+	return e, true
+}
+
+// ExplainWriter writes explanations as text, grouped by range, or as JSON, one object per line.
+type ExplainWriter struct {
+	w         io.Writer
+	format    string
+	ranges    []Range
+	radix     int
+	lastRange int
+}
+
+// NewExplainWriter returns a writer for explanations of IDs of the given ranges.
+func NewExplainWriter(w io.Writer, format string, ranges []Range, radix int) (*ExplainWriter, error) {
+	if format != "text" && format != "json" {
+		return nil, fmt.Errorf("unknown explain format '%s', expected text or json", format)
+	}
+	return &ExplainWriter{w: w, format: format, ranges: ranges, radix: radix}, nil
+}
+
+func (ew *ExplainWriter) Write(e Explanation) error {
+	if ew.format == "json" {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(ew.w, "%s\n", data)
+		return err
+	}
+
+	if e.Range != ew.lastRange {
+		r := ew.ranges[e.Range-1]
+		if _, err := fmt.Fprintf(ew.w, "range %d (%s-%s):\n", e.Range, r.Start.Text(ew.radix), r.End.Text(ew.radix)); err != nil {
+			return err
+		}
+		ew.lastRange = e.Range
+	}
+
+	reason := e.Rule
+	if e.Block != "" {
+		reason = fmt.Sprintf("%s repeated %d times", e.Block, e.Times)
+	}
+	_, err := fmt.Fprintf(ew.w, "  %s: %s\n", e.ID, reason)
+	return err
+}
+
+// ExplainIDs returns an emit function for Evaluator.Evaluate that explains every found ID.
+func ExplainIDs(ew *ExplainWriter, rule IDRule) func(FoundID) error {
+	return func(f FoundID) error {
+		e, ok := Explain(rule, f.ID.Text(ew.radix))
+		if !ok {
+			return fmt.Errorf("ID %s does not match %v", f.ID.Text(ew.radix), rule)
+		}
+		e.Range = f.Range + 1
+		return ew.Write(e)
+	}
+}
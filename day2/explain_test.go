@@ -0,0 +1,69 @@
+package day2
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		rule   IDRule
+		digits string
+		want   Explanation
+	}{
+		{rule: PartOneRule, digits: "222222", want: Explanation{ID: "222222", Rule: "repeats(2)", Block: "222", Times: 2}},
+		{rule: PartTwoRule, digits: "222222", want: Explanation{ID: "222222", Rule: "repeats-at-least(2)", Block: "2", Times: 6}},
+		{rule: PartTwoRule, digits: "565656", want: Explanation{ID: "565656", Rule: "repeats-at-least(2)", Block: "56", Times: 3}},
+		{rule: Palindrome{}, digits: "121", want: Explanation{ID: "121", Rule: "palindrome"}},
+		{rule: Or(Palindrome{}, PartOneRule), digits: "1212", want: Explanation{ID: "1212", Rule: "repeats(2)", Block: "12", Times: 2}},
+		{rule: And(Palindrome{}, Repeats{3}), digits: "121121121", want: Explanation{ID: "121121121", Rule: "and(palindrome, repeats(3))", Block: "121", Times: 3}},
+	}
+
+	for _, tt := range tests {
+		got, ok := Explain(tt.rule, tt.digits)
+		if !ok || got != tt.want {
+			t.Errorf("Explain(%v, %s) = %+v, %t, want %+v", tt.rule, tt.digits, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := Explain(PartOneRule, "123"); ok {
+		t.Errorf("Explain(%v, 123): expected a valid ID", PartOneRule)
+	}
+}
+
+func TestExplainWriter(t *testing.T) {
+	ranges, err := ParseRanges("11-22,30-32,95-115", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		format string
+		want   string
+	}{
+		{format: "text", want: "range 1 (11-22):\n  11: 1 repeated 2 times\n  22: 2 repeated 2 times\n" +
+			"range 3 (95-115):\n  99: 9 repeated 2 times\n  111: 1 repeated 3 times\n"},
+		{format: "json", want: `{"range":1,"id":"11","rule":"repeats-at-least(2)","block":"1","times":2}` + "\n" +
+			`{"range":1,"id":"22","rule":"repeats-at-least(2)","block":"2","times":2}` + "\n" +
+			`{"range":3,"id":"99","rule":"repeats-at-least(2)","block":"9","times":2}` + "\n" +
+			`{"range":3,"id":"111","rule":"repeats-at-least(2)","block":"1","times":3}` + "\n"},
+	} {
+		var buf bytes.Buffer
+		ew, err := NewExplainWriter(&buf, tt.format, ranges, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := &Evaluator{Rule: PartTwoRule, Radix: 10, Workers: 2, ChunkSize: 3}
+		if _, err := e.Evaluate(context.Background(), ranges, ExplainIDs(ew, PartTwoRule)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+	}
+
+	if _, err := NewExplainWriter(&bytes.Buffer{}, "xml", ranges, 10); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,103 @@
+package day2
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Semantics decides how IDs covered by several ranges are counted.
+type Semantics int
+
+const (
+	// PerRange sums every range on its own, so an ID in overlapping ranges counts once per range.
+	PerRange Semantics = iota
+	// Union sums the IDs of the union of all ranges, so every ID counts once.
+	Union
+)
+
+func ParseSemantics(s string) (Semantics, error) {
+	switch s {
+	case "per-range":
+		return PerRange, nil
+	case "union":
+		return Union, nil
+	}
+	return 0, fmt.Errorf("unknown semantics '%s', expected per-range or union", s)
+}
+
+func (s Semantics) String() string {
+	if s == Union {
+		return "union"
+	}
+	return "per-range"
+}
+
+// MergedRange is a range of the union together with the (0 based) indexes of the input ranges it
+// covers. Overlapping holds the inputs that share IDs with another input, rather than only touching it.
+type MergedRange struct {
+	Range
+	Inputs      []int
+	Overlapping []int
+}
+
+// Normalize merges overlapping and adjacent ranges into a sorted union. It fails if a range starts after its end.
+func Normalize(ranges []Range) ([]MergedRange, error) {
+	order := make([]int, len(ranges))
+	for i, r := range ranges {
+		if r.Start.Cmp(r.End) > 0 {
+			return nil, fmt.Errorf("range %d (%v) starts after its end", i+1, r)
+		}
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return ranges[a].Start.Cmp(ranges[b].Start) })
+
+	merged := []MergedRange{}
+	// endInput is the input reaching the end of the last merged range
+	endInput := 0
+	for _, i := range order {
+		r := ranges[i]
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			// adjacent if the range starts right after the last one ends
+			if r.Start.Cmp(new(big.Int).Add(last.End, one)) <= 0 {
+				if r.Start.Cmp(last.End) <= 0 {
+					last.Overlapping = append(last.Overlapping, endInput, i)
+				}
+				if r.End.Cmp(last.End) > 0 {
+					last.End = r.End
+					endInput = i
+				}
+				last.Inputs = append(last.Inputs, i)
+				continue
+			}
+		}
+		merged = append(merged, MergedRange{Range: r, Inputs: []int{i}})
+		endInput = i
+	}
+
+	for i := range merged {
+		slices.Sort(merged[i].Inputs)
+		slices.Sort(merged[i].Overlapping)
+		merged[i].Overlapping = slices.Compact(merged[i].Overlapping)
+	}
+	return merged, nil
+}
+
+// Apply returns the ranges to sum under the semantics and the merges of the union of the ranges.
+// PerRange sums the ranges as they are, the merges then show which of them overlap.
+func (s Semantics) Apply(ranges []Range) ([]Range, []MergedRange, error) {
+	merged, err := Normalize(ranges)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == PerRange {
+		return ranges, merged, nil
+	}
+
+	union := make([]Range, len(merged))
+	for i, m := range merged {
+		union[i] = m.Range
+	}
+	return union, merged, nil
+}
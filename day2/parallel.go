@@ -0,0 +1,153 @@
+package day2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// DefaultChunkSize is the number of IDs a worker checks at once.
+const DefaultChunkSize = 1 << 16
+
+// FoundID is an invalid ID of the range with the (0 based) index Range.
+type FoundID struct {
+	Range int
+	ID    *big.Int
+}
+
+// Evaluator checks ranges against a rule with a pool of workers, each checking one chunk of a range at a time.
+type Evaluator struct {
+	Rule  IDRule
+	Radix int
+	// Workers defaults to GOMAXPROCS and ChunkSize to DefaultChunkSize.
+	Workers   int
+	ChunkSize int64
+}
+
+// Evaluate returns the sum of the IDs of all ranges that match the rule. If emit is not nil it is
+// called for every invalid ID, in input order of the ranges and ascending within a range, no
+// matter how many workers there are. Without emit, rules with a closed form skip the workers.
+// Evaluate stops at the first error of emit or when ctx is done.
+func (e *Evaluator) Evaluate(ctx context.Context, ranges []Range, emit func(FoundID) error) (*big.Int, error) {
+	if _, ok := e.Rule.(rangeCounter); ok && emit == nil {
+		return Sum(ranges, e.Radix, e.Rule), ctx.Err()
+	}
+
+	workers := e.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunkSize := e.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type chunk struct {
+		index      int
+		rangeIndex int
+		r          Range
+		wrongIds   []*big.Int
+		sum        *big.Int
+	}
+	chunks := make(chan chunk)
+	results := make(chan chunk, workers)
+	// window limits the chunks that are handed out but not yet emitted, so the results waiting
+	// for an earlier chunk stay bounded
+	window := make(chan struct{}, 2*workers)
+
+	go func() {
+		defer close(chunks)
+		index := 0
+		step := big.NewInt(chunkSize)
+		for i, r := range ranges {
+			for start := maxInt(r.Start, one); start.Cmp(r.End) <= 0; start = new(big.Int).Add(start, step) {
+				end := minInt(new(big.Int).Add(start, big.NewInt(chunkSize-1)), r.End)
+				select {
+				case window <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case chunks <- chunk{index: index, rangeIndex: i, r: Range{start, end}}:
+				case <-ctx.Done():
+					return
+				}
+				index++
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				c.wrongIds, c.sum = filterRange(c.r, e.Radix, e.Rule)
+				select {
+				case results <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// chunks finish in any order, they are summed and emitted in the order they were handed out
+	sum := new(big.Int)
+	pending := map[int]chunk{}
+	next := 0
+	for c := range results {
+		pending[c.index] = c
+		for c, ok := pending[next]; ok; c, ok = pending[next] {
+			delete(pending, next)
+			next++
+			<-window
+
+			sum.Add(sum, c.sum)
+			if emit == nil {
+				continue
+			}
+			for _, id := range c.wrongIds {
+				if err := emit(FoundID{Range: c.rangeIndex, ID: id}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// WriteIDs returns an emit function that writes every ID on its own line.
+func WriteIDs(w io.Writer, radix int) func(FoundID) error {
+	return func(f FoundID) error {
+		_, err := fmt.Fprintln(w, f.ID.Text(radix))
+		return err
+	}
+}
+
+// SendIDs returns an emit function that sends every ID to ch, giving up once ctx is done.
+func SendIDs(ctx context.Context, ch chan<- FoundID) func(FoundID) error {
+	return func(f FoundID) error {
+		select {
+		case ch <- f:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
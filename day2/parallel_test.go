@@ -0,0 +1,128 @@
+package day2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestEvaluatorDeterministic(t *testing.T) {
+	ranges, err := ParseRanges(example+",1-30000", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := Or(PartTwoRule, Palindrome{})
+
+	var want []FoundID
+	wantSum := new(big.Int)
+	for i, r := range ranges {
+		wrongIds, sum := filterRange(r, 10, rule)
+		for _, id := range wrongIds {
+			want = append(want, FoundID{Range: i, ID: id})
+		}
+		wantSum.Add(wantSum, sum)
+	}
+
+	for _, workers := range []int{1, 2, 3, 8} {
+		e := &Evaluator{Rule: rule, Radix: 10, Workers: workers, ChunkSize: 97}
+		var got []FoundID
+		sum, err := e.Evaluate(context.Background(), ranges, func(f FoundID) error {
+			got = append(got, f)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum.Cmp(wantSum) != 0 {
+			t.Errorf("%d workers: got sum %d, want %d", workers, sum, wantSum)
+		}
+		if !slices.EqualFunc(got, want, func(a, b FoundID) bool { return a.Range == b.Range && a.ID.Cmp(b.ID) == 0 }) {
+			t.Errorf("%d workers: found IDs differ, got %d IDs, want %d", workers, len(got), len(want))
+		}
+	}
+}
+
+func TestEvaluatorClosedForm(t *testing.T) {
+	ranges, err := ParseRanges("1-99999999999999999999999", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &Evaluator{Rule: PartTwoRule, Radix: 10}
+	sum, err := e.Evaluate(context.Background(), ranges, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Sum(ranges, 10, PartTwoRule); sum.Cmp(want) != 0 {
+		t.Errorf("got %d, want %d", sum, want)
+	}
+}
+
+func TestEvaluatorCancel(t *testing.T) {
+	ranges, err := ParseRanges("1-100000000000", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &Evaluator{Rule: Palindrome{}, Radix: 10, Workers: 4, ChunkSize: 1000}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	found := 0
+	_, err = e.Evaluate(ctx, ranges, func(FoundID) error {
+		if found++; found == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	stop := errors.New("stop")
+	_, err = e.Evaluate(context.Background(), ranges, func(FoundID) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("got error %v, want %v", err, stop)
+	}
+}
+
+func TestWriteIDs(t *testing.T) {
+	ranges, err := ParseRanges("a-30", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	e := &Evaluator{Rule: PartOneRule, Radix: 16, Workers: 2, ChunkSize: 5}
+	if _, err := e.Evaluate(context.Background(), ranges, WriteIDs(&buf, 16)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "11\n22\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSendIDs(t *testing.T) {
+	ranges, err := ParseRanges("10-40,95-115", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan FoundID)
+	errc := make(chan error, 1)
+	go func() {
+		e := &Evaluator{Rule: PartTwoRule, Radix: 10, Workers: 3, ChunkSize: 4}
+		_, err := e.Evaluate(context.Background(), ranges, SendIDs(context.Background(), ch))
+		close(ch)
+		errc <- err
+	}()
+
+	var got []string
+	for f := range ch {
+		got = append(got, f.ID.String())
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"11", "22", "33", "99", "111"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,280 @@
+package day2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IDRule decides whether an ID is invalid. IDs are positive and passed as their digits in the
+// radix of the input, without leading zeros.
+type IDRule interface {
+	Invalid(digits string) bool
+	// String returns the rule in the syntax ParseRule accepts.
+	String() string
+}
+
+// Repeats matches IDs made of a block repeated exactly Times times, e.g. 123123 for 2.
+type Repeats struct {
+	Times int
+}
+
+func (r Repeats) Invalid(digits string) bool {
+	n := len(digits)
+	return n%r.Times == 0 && isRepetition(digits, n/r.Times)
+}
+
+func (r Repeats) String() string {
+	return fmt.Sprintf("repeats(%d)", r.Times)
+}
+
+// RepeatsAtLeast matches IDs made of a block repeated Times or more times, e.g. 121212 for 2.
+type RepeatsAtLeast struct {
+	Times int
+}
+
+func (r RepeatsAtLeast) Invalid(digits string) bool {
+	n := len(digits)
+	for blockLength := 1; blockLength <= n/r.Times; blockLength++ {
+		if n%blockLength == 0 && isRepetition(digits, blockLength) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RepeatsAtLeast) String() string {
+	return fmt.Sprintf("repeats-at-least(%d)", r.Times)
+}
+
+// isRepetition reports whether digits consists of copies of its first blockLength digits.
+func isRepetition(digits string, blockLength int) bool {
+	for i := blockLength; i < len(digits); i += blockLength {
+		if digits[:blockLength] != digits[i:i+blockLength] {
+			return false
+		}
+	}
+	return true
+}
+
+// Palindrome matches IDs that read the same backwards.
+type Palindrome struct{}
+
+func (Palindrome) Invalid(digits string) bool {
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		if digits[i] != digits[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func (Palindrome) String() string {
+	return "palindrome"
+}
+
+// DigitSumDivisible matches IDs whose digit sum is divisible by Divisor.
+type DigitSumDivisible struct {
+	Divisor int
+}
+
+func (r DigitSumDivisible) Invalid(digits string) bool {
+	sum := 0
+	for _, c := range digits {
+		// digits are 0-9 followed by a-z, as written by big.Int.Text
+		if c >= 'a' {
+			sum += int(c-'a') + 10
+		} else {
+			sum += int(c - '0')
+		}
+	}
+	return sum%r.Divisor == 0
+}
+
+func (r DigitSumDivisible) String() string {
+	return fmt.Sprintf("digitsum(%d)", r.Divisor)
+}
+
+type and []IDRule
+
+// And matches IDs matched by all rules.
+func And(rules ...IDRule) IDRule {
+	return and(rules)
+}
+
+func (a and) Invalid(digits string) bool {
+	for _, r := range a {
+		if !r.Invalid(digits) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a and) String() string {
+	return "and(" + joinRules(a) + ")"
+}
+
+type or []IDRule
+
+// Or matches IDs matched by any of the rules.
+func Or(rules ...IDRule) IDRule {
+	return or(rules)
+}
+
+func (o or) Invalid(digits string) bool {
+	for _, r := range o {
+		if r.Invalid(digits) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o or) String() string {
+	return "or(" + joinRules(o) + ")"
+}
+
+type not struct {
+	rule IDRule
+}
+
+// Not matches IDs the rule does not match.
+func Not(rule IDRule) IDRule {
+	return not{rule}
+}
+
+func (n not) Invalid(digits string) bool {
+	return !n.rule.Invalid(digits)
+}
+
+func (n not) String() string {
+	return "not(" + n.rule.String() + ")"
+}
+
+func joinRules(rules []IDRule) string {
+	s := make([]string, len(rules))
+	for i, r := range rules {
+		s[i] = r.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// ParseRule parses a rule like "or(repeats(2), and(palindrome, not(digitsum(3))))". The rules are
+// repeats(k), repeats-at-least(k), palindrome, digitsum(n), and(...), or(...) and not(rule).
+func ParseRule(s string) (IDRule, error) {
+	p := &ruleParser{s: s}
+	rule, err := p.rule()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected '%s'", p.s[p.pos:])
+	}
+	return rule, nil
+}
+
+type ruleParser struct {
+	s   string
+	pos int
+}
+
+func (p *ruleParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid rule '%s' at offset %d: %s", p.s, p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *ruleParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// word reads a rule name or number.
+func (p *ruleParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= '0' && p.s[p.pos] <= '9' || p.s[p.pos] == '-') {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *ruleParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return p.errorf("expected '%c'", c)
+	}
+	p.pos++
+	return nil
+}
+
+// number reads a "(n)" argument of at least minimum.
+func (p *ruleParser) number(minimum int) (int, error) {
+	if err := p.expect('('); err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(p.word())
+	if err != nil || n < minimum {
+		return 0, p.errorf("expected a number of at least %d", minimum)
+	}
+	return n, p.expect(')')
+}
+
+// rules reads a "(rule, ...)" argument list.
+func (p *ruleParser) rules() ([]IDRule, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	rules := []IDRule{}
+	for {
+		r, err := p.rule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return rules, p.expect(')')
+	}
+}
+
+func (p *ruleParser) rule() (IDRule, error) {
+	start := p.pos
+	switch name := p.word(); name {
+	case "repeats":
+		n, err := p.number(2)
+		return Repeats{n}, err
+	case "repeats-at-least":
+		n, err := p.number(2)
+		return RepeatsAtLeast{n}, err
+	case "palindrome":
+		return Palindrome{}, nil
+	case "digitsum":
+		n, err := p.number(1)
+		return DigitSumDivisible{n}, err
+	case "and":
+		rules, err := p.rules()
+		return And(rules...), err
+	case "or":
+		rules, err := p.rules()
+		return Or(rules...), err
+	case "not":
+		rules, err := p.rules()
+		if err == nil && len(rules) != 1 {
+			return nil, p.errorf("not takes a single rule")
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Not(rules[0]), nil
+	default:
+		p.pos = start
+		p.skipSpace()
+		return nil, p.errorf("unknown rule '%s'", name)
+	}
+}
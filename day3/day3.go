@@ -0,0 +1,315 @@
+package day3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+const (
+	CONSOLE_RESET   = "\u001B[0m"
+	CONSOLE_RED     = "\u001B[31m"
+	CONSOLE_GREEN   = "\u001B[32m"
+	CONSOLE_YELLOW  = "\u001B[33m"
+	CONSOLE_BLUE    = "\u001B[34m"
+	CONSOLE_MAGENTA = "\u001B[35m"
+	CONSOLE_CYAN    = "\u001B[36m"
+)
+
+// BatteryBank is a single line of cells, banks of one input may differ in length.
+type BatteryBank struct {
+	CellVoltate         []string
+	CellIndexesToTurnOn []int
+	// selections caches the cells of the max joltage per cell count, it is shared by copies of the bank
+	selections *selectionCache
+}
+
+// selectionCache holds the selections of a bank per cell count. Copies of a bank may be used
+// concurrently, so it is locked.
+type selectionCache struct {
+	mu         sync.Mutex
+	selections map[int][]int
+}
+
+func (c *selectionCache) get(cellCount int) ([]int, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	selection, ok := c.selections[cellCount]
+	return selection, ok
+}
+
+func (c *selectionCache) put(cellCount int, selection []int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.selections[cellCount] = selection
+}
+
+// InvalidCellError is returned by NewBatteryBank for a cell that is not a digit.
+type InvalidCellError struct {
+	Index int
+	Cell  byte
+}
+
+func (e *InvalidCellError) Error() string {
+	return fmt.Sprintf("invalid cell voltage '%c' at index %d", e.Cell, e.Index)
+}
+
+func NewBatteryBank(s string) (b BatteryBank, err error) {
+	if i := strings.IndexFunc(s, isNotDigit); i >= 0 {
+		return b, &InvalidCellError{Index: i, Cell: s[i]}
+	}
+	return BatteryBank{
+		CellVoltate: strings.Split(s, ""),
+		selections:  &selectionCache{selections: map[int][]int{}},
+	}, nil
+}
+
+// Len returns the number of cells of the bank.
+func (b BatteryBank) Len() int {
+	return len(b.CellVoltate)
+}
+
+// String returns the cells of the bank as plain digits. Renderer highlights the cells that are
+// turned on, in a format picked by the caller, e.g. with DetectFormat.
+func (b BatteryBank) String() string {
+	return strings.Join(b.CellVoltate, "")
+}
+
+// GetMaxJoltage returns the largest joltage of cellCount cells and turns those cells on.
+func (b *BatteryBank) GetMaxJoltage(cellCount int) (int, error) {
+	selection, err := b.MaxSelection(cellCount)
+	if err != nil {
+		return 0, err
+	}
+	b.CellIndexesToTurnOn = slices.Clone(selection)
+
+	sum := 0
+	for _, i := range b.CellIndexesToTurnOn {
+		digit := int(b.CellVoltate[i][0] - '0')
+		if sum > (math.MaxInt-digit)/10 {
+			return 0, fmt.Errorf("joltage of %d cells: %w", cellCount, ErrOverflow)
+		}
+		sum = sum*10 + digit
+	}
+	return sum, nil
+}
+
+// ErrOverflow is returned if a joltage does not fit into an int, GetMaxJoltageBig has no such limit.
+var ErrOverflow = errors.New("joltage overflows int")
+
+// GetMaxJoltageBig is GetMaxJoltage for any number of cells.
+func (b *BatteryBank) GetMaxJoltageBig(cellCount int) (*big.Int, error) {
+	selection, err := b.MaxSelection(cellCount)
+	if err != nil {
+		return nil, err
+	}
+	b.CellIndexesToTurnOn = slices.Clone(selection)
+
+	joltage := new(big.Int)
+	if cellCount > 0 {
+		joltage.SetString(b.Digits(selection), 10)
+	}
+	return joltage, nil
+}
+
+// MaxSelection returns the indexes of the cellCount cells that form the largest joltage.
+// Selections are cached per cell count, the returned slice must not be modified.
+func (b *BatteryBank) MaxSelection(cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+
+	if selection, ok := b.selections.get(cellCount); ok {
+		return selection, nil
+	}
+	selection := b.computeMaxJoltage(cellCount)
+	b.selections.put(cellCount, selection)
+	return selection, nil
+}
+
+// checkCellCount returns an error if the bank does not have cellCount cells to select.
+func (b BatteryBank) checkCellCount(cellCount int) error {
+	if cellCount > b.Len() {
+		return fmt.Errorf("requested cell count exceeds length of the battery bank (%d > %d)", cellCount, b.Len())
+	}
+	if cellCount < 0 {
+		return fmt.Errorf("requested cell count must not be negative, got %d", cellCount)
+	}
+	return nil
+}
+
+// MaxSelections returns the selections of the largest joltage for every cell count from 1 to
+// Len(), the selection of k cells at index k-1, and caches all of them.
+//
+// For every count the cells are chosen greedily from the left: the next cell is the first
+// occurrence of the highest digit that still leaves enough cells for the rest of the selection.
+// With a table of the next occurrence of every digit, each cell is found in constant time, so
+// all selections take O(n^2), which is the size of the result.
+func (b *BatteryBank) MaxSelections() [][]int {
+	n := b.Len()
+	// next[i][d] is the first index >= i of the digit d, n if there is none
+	next := make([][10]int, n+1)
+	for d := range 10 {
+		next[n][d] = n
+	}
+	for i := n - 1; i >= 0; i-- {
+		next[i] = next[i+1]
+		next[i][b.CellVoltate[i][0]-'0'] = i
+	}
+
+	selections := make([][]int, n)
+	for cellCount := 1; cellCount <= n; cellCount++ {
+		selection := make([]int, cellCount)
+		start := 0
+		for j := range cellCount {
+			// the j-th cell must leave cellCount-j-1 cells after it
+			last := n - cellCount + j
+			for d := 9; d >= 0; d-- {
+				if next[start][d] <= last {
+					selection[j] = next[start][d]
+					break
+				}
+			}
+			start = selection[j] + 1
+		}
+
+		selections[cellCount-1] = selection
+		b.selections.put(cellCount, selection)
+	}
+	return selections
+}
+
+// Digits returns the digits of the selected cells, which is the joltage of the selection.
+func (b BatteryBank) Digits(selection []int) string {
+	digits := make([]byte, len(selection))
+	for i, index := range selection {
+		digits[i] = b.CellVoltate[index][0]
+	}
+	return string(digits)
+}
+
+// computeMaxJoltage selects the cellCount cells that form the largest number, keeping their order.
+// It walks the bank once, keeping the selection on a stack: a cell replaces the smaller cells on top
+// of the stack as long as enough cells remain to fill the selection. Every cell is pushed and
+// popped at most once, so it runs in O(n) for any cell count.
+func (b *BatteryBank) computeMaxJoltage(cellCount int) []int {
+	// number of cells that can still be left out
+	skippable := b.Len() - cellCount
+	stack := make([]int, 0, b.Len())
+	for i, voltage := range b.CellVoltate {
+		// equal cells stay, so the earliest cells are chosen among equal selections
+		for skippable > 0 && len(stack) > 0 && b.CellVoltate[stack[len(stack)-1]] < voltage {
+			stack = stack[:len(stack)-1]
+			skippable--
+		}
+		stack = append(stack, i)
+	}
+
+	// cells left over at the end are the smallest tail
+	return stack[:cellCount]
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+// parseBatteryBanks parses one bank per line, blank lines before the first bank are skipped.
+func parseBatteryBanks(rawBanks string) (banks []BatteryBank, err error) {
+	for i, line := range strings.Split(rawBanks, "\n") {
+		bankStr := strings.TrimSpace(line)
+		if bankStr == "" {
+			if len(banks) == 0 {
+				continue
+			}
+			return nil, input.Errorf(i+1, 1, "empty bank string")
+		}
+
+		// NewBatteryBank verifies that every cell is a single digit
+		bank, err := NewBatteryBank(bankStr)
+		if cellErr := (*InvalidCellError)(nil); errors.As(err, &cellErr) {
+			indent := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+			return nil, input.Errorf(i+1, indent+cellErr.Index+1, "invalid cell voltage '%c'", cellErr.Cell)
+		}
+		banks = append(banks, bank)
+	}
+	if len(banks) == 0 {
+		return nil, input.Errorf(1, 1, "empty bank string")
+	}
+
+	return banks, nil
+}
+
+func init() {
+	solver.Register(3, func() solver.Solver { return &Solver{} })
+}
+
+// The number of cells turned on in part one and part two.
+const (
+	partOneCells = 2
+	partTwoCells = 12
+)
+
+// Solver sums the max joltage of all banks with 2 (part one) and 12 (part two) cells turned on.
+type Solver struct {
+	batteryBanks []BatteryBank
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.batteryBanks, err = ParseBatteryBanks(input)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return s.sumMaxJoltage(partOneCells)
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return s.sumMaxJoltage(partTwoCells)
+}
+
+func (s *Solver) sumMaxJoltage(cellCount int) (int, error) {
+	sum := 0
+	for i, bank := range s.batteryBanks {
+		maxJoltage, err := bank.GetMaxJoltage(cellCount)
+		if err != nil {
+			return 0, fmt.Errorf("bank %d: %w", i+1, err)
+		}
+		if sum > math.MaxInt-maxJoltage {
+			return 0, fmt.Errorf("total of %d banks: %w", i+1, ErrOverflow)
+		}
+		sum += maxJoltage
+	}
+	return sum, nil
+}
+
+// ParseBatteryBanks parses one bank per line.
+func ParseBatteryBanks(rawBanks string) ([]BatteryBank, error) {
+	return parseBatteryBanks(strings.TrimRightFunc(rawBanks, unicode.IsSpace))
+}
+
+// SumMaxJoltageBig returns the total of the max joltages of cellCount cells of all banks, without overflowing.
+func SumMaxJoltageBig(banks []BatteryBank, cellCount int) (*big.Int, error) {
+	sum := new(big.Int)
+	for i, bank := range banks {
+		maxJoltage, err := bank.GetMaxJoltageBig(cellCount)
+		if err != nil {
+			return nil, fmt.Errorf("bank %d: %w", i+1, err)
+		}
+		sum.Add(sum, maxJoltage)
+	}
+	return sum, nil
+}
@@ -0,0 +1,308 @@
+package day3
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = `987654321111111
+811111111111119
+234234234234278
+818181911112111`
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 3, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 357, PartTwo: 3121910778619},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 357, PartTwo: 3121910778619},
+		{Name: "exactly twelve cells", Input: "123456789123", PartOne: 93, PartTwo: 123456789123},
+		{Name: "all nines", Input: "99999999999999", PartOne: 99, PartTwo: 999999999999},
+		{Name: "mixed lengths", Input: "9876543211111111111\n123456789123", PartOne: 98 + 93, PartTwo: 987654321111 + 123456789123},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 3, []solvertest.ErrorCase{
+		{Name: "invalid voltage", Input: "123\n1a3", Line: 2, Column: 2},
+		{Name: "empty bank", Input: "123\n\n456", Line: 2, Column: 1},
+		{Name: "after leading blank lines", Input: "\n\n123\n12a", Line: 4, Column: 3},
+		{Name: "indented", Input: "123\n  1a3", Line: 2, Column: 4},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 3)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 3, example)
+}
+
+func TestGetMaxJoltage(t *testing.T) {
+	tests := []struct {
+		bank      string
+		cellCount int
+		want      int
+	}{
+		{bank: "987654321111111", cellCount: 2, want: 98},
+		{bank: "811111111111119", cellCount: 2, want: 89},
+		{bank: "234234234234278", cellCount: 2, want: 78},
+		{bank: "818181911112111", cellCount: 2, want: 92},
+		{bank: "987654321111111", cellCount: 12, want: 987654321111},
+		{bank: "811111111111119", cellCount: 12, want: 811111111119},
+		{bank: "234234234234278", cellCount: 12, want: 434234234278},
+		{bank: "818181911112111", cellCount: 12, want: 888911112111},
+		{bank: "54321", cellCount: 5, want: 54321},
+		{bank: "19191", cellCount: 2, want: 99},
+	}
+
+	for _, tt := range tests {
+		banks, err := parseBatteryBanks(tt.bank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := banks[0].GetMaxJoltage(tt.cellCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("GetMaxJoltage(%s, %d) = %d, want %d", tt.bank, tt.cellCount, got, tt.want)
+		}
+	}
+}
+
+func TestGetMaxJoltageTooManyCells(t *testing.T) {
+	banks, err := parseBatteryBanks("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := banks[0].GetMaxJoltage(6); err == nil {
+		t.Error("expected an error when requesting more cells than the bank holds")
+	}
+}
+
+func TestParseBatteryBanksMixedLength(t *testing.T) {
+	banks, err := parseBatteryBanks("123\n98765\n7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		length  int
+		joltage int
+	}{{3, 23}, {5, 98}, {1, 7}} {
+		if banks[i].Len() != want.length {
+			t.Errorf("bank %d: got length %d, want %d", i+1, banks[i].Len(), want.length)
+		}
+		cellCount := min(2, want.length)
+		if got, err := banks[i].GetMaxJoltage(cellCount); err != nil || got != want.joltage {
+			t.Errorf("bank %d: GetMaxJoltage(%d) = %d, %v, want %d", i+1, cellCount, got, err, want.joltage)
+		}
+	}
+
+	s := &Solver{}
+	if err := s.Parse("123456789123\n1234"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PartTwo(); err == nil || !strings.Contains(err.Error(), "bank 2") {
+		t.Errorf("expected an error for bank 2, which is shorter than 12 cells, got %v", err)
+	}
+}
+
+// maxJoltageExhaustive tries every selection of cellCount cells.
+func maxJoltageExhaustive(bank string, cellCount int) int {
+	best := 0
+	for mask := 0; mask < 1<<len(bank); mask++ {
+		if bits.OnesCount(uint(mask)) != cellCount {
+			continue
+		}
+		joltage := 0
+		for i := range len(bank) {
+			if mask&(1<<i) != 0 {
+				joltage = joltage*10 + int(bank[i]-'0')
+			}
+		}
+		best = max(best, joltage)
+	}
+	return best
+}
+
+func TestMaxJoltageMatchesExhaustiveSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for range 500 {
+		digits := make([]byte, 1+rng.Intn(12))
+		// few distinct digits make ties likely
+		spread := 1 + rng.Intn(9)
+		for i := range digits {
+			digits[i] = byte('0' + rng.Intn(spread+1))
+		}
+		bank := string(digits)
+
+		for cellCount := 1; cellCount <= len(bank); cellCount++ {
+			b, err := NewBatteryBank(bank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := b.GetMaxJoltage(cellCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := maxJoltageExhaustive(bank, cellCount); got != want {
+				t.Fatalf("GetMaxJoltage(%s, %d) = %d, want %d", bank, cellCount, got, want)
+			}
+			if len(b.CellIndexesToTurnOn) != cellCount || !slices.IsSorted(b.CellIndexesToTurnOn) {
+				t.Fatalf("GetMaxJoltage(%s, %d): invalid selection %v", bank, cellCount, b.CellIndexesToTurnOn)
+			}
+		}
+	}
+}
+
+func TestMaxSelections(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	for range 200 {
+		digits := make([]byte, 1+rng.Intn(40))
+		for i := range digits {
+			digits[i] = byte('0' + rng.Intn(1+rng.Intn(10)))
+		}
+		bank, err := NewBatteryBank(string(digits))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		selections := bank.MaxSelections()
+		if len(selections) != bank.Len() {
+			t.Fatalf("%s: got %d selections, want %d", digits, len(selections), bank.Len())
+		}
+		for cellCount := 1; cellCount <= bank.Len(); cellCount++ {
+			want := bank.computeMaxJoltage(cellCount)
+			if !slices.Equal(selections[cellCount-1], want) {
+				t.Fatalf("%s, %d cells: got %v, want %v", digits, cellCount, selections[cellCount-1], want)
+			}
+			// served from the cache
+			if cached, err := bank.MaxSelection(cellCount); err != nil || &cached[0] != &selections[cellCount-1][0] {
+				t.Fatalf("%s, %d cells: selection is not cached", digits, cellCount)
+			}
+		}
+	}
+
+	bank, err := NewBatteryBank("818181911112111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	curve := []string{}
+	for _, selection := range bank.MaxSelections() {
+		curve = append(curve, bank.Digits(selection))
+	}
+	if curve[1] != "92" || curve[11] != "888911112111" || curve[14] != "818181911112111" {
+		t.Errorf("unexpected joltage curve %v", curve)
+	}
+}
+
+func TestGetMaxJoltageOverflow(t *testing.T) {
+	tests := []struct {
+		bank     string
+		overflow bool
+	}{
+		{bank: strings.Repeat("9", 18)},
+		{bank: "9223372036854775807"},
+		{bank: "9223372036854775808", overflow: true},
+		{bank: strings.Repeat("9", 19), overflow: true},
+	}
+
+	for _, tt := range tests {
+		b, err := NewBatteryBank(tt.bank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := b.GetMaxJoltage(len(tt.bank))
+		if tt.overflow != errors.Is(err, ErrOverflow) {
+			t.Errorf("GetMaxJoltage(%s): got %d, %v, overflow %t", tt.bank, got, err, tt.overflow)
+		}
+
+		joltage, err := b.GetMaxJoltageBig(len(tt.bank))
+		if err != nil || joltage.String() != tt.bank {
+			t.Errorf("GetMaxJoltageBig(%s) = %v, %v", tt.bank, joltage, err)
+		}
+	}
+}
+
+func TestSumMaxJoltageBig(t *testing.T) {
+	long := strings.Repeat("1234567890", 50)
+	banks, err := ParseBatteryBanks(long + "\n" + long + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	joltage, err := banks[0].GetMaxJoltageBig(300)
+	if err != nil || len(joltage.String()) != 300 {
+		t.Fatalf("GetMaxJoltageBig(300) = %v, %v, want 300 digits", joltage, err)
+	}
+	want := new(big.Int).Set(joltage)
+	want.Mul(want, big.NewInt(2))
+	got, err := SumMaxJoltageBig(banks, 300)
+	if err != nil || got.Cmp(want) != 0 {
+		t.Errorf("SumMaxJoltageBig = %v, %v, want %v", got, err, want)
+	}
+
+	s := &Solver{}
+	if err := s.Parse("9000000000000000000\n9000000000000000000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.sumMaxJoltage(19); !errors.Is(err, ErrOverflow) {
+		t.Errorf("sum of two 19 digit joltages: got %v, want %v", err, ErrOverflow)
+	}
+	if _, err := s.sumMaxJoltage(20); err == nil || errors.Is(err, ErrOverflow) {
+		t.Errorf("20 cells of 19: got %v, want a cell count error", err)
+	}
+}
+
+func TestSolverPartsConcurrently(t *testing.T) {
+	s := &Solver{}
+	if err := s.Parse(example); err != nil {
+		t.Fatal(err)
+	}
+
+	// the parts share the selection caches of the banks, which are safe to fill at the same time
+	done := make(chan int)
+	for _, part := range []func() (int, error){s.PartOne, s.PartTwo} {
+		go func() {
+			answer, err := part()
+			if err != nil {
+				t.Error(err)
+			}
+			done <- answer
+		}()
+	}
+	if got := <-done + <-done; got != 357+3121910778619 {
+		t.Errorf("got answers summing to %d, want %d", got, 357+3121910778619)
+	}
+}
+
+func TestCellIndexesToTurnOnIsACopy(t *testing.T) {
+	b, err := NewBatteryBank("818181911112111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.GetMaxJoltage(2); err != nil {
+		t.Fatal(err)
+	}
+	b.CellIndexesToTurnOn[0] = 3
+
+	if selection, err := b.MaxSelection(2); err != nil || !slices.Equal(selection, []int{6, 11}) {
+		t.Errorf("MaxSelection(2) after changing the cells turned on = %v, %v, want [6 11]", selection, err)
+	}
+}
+
+func TestNewBatteryBankInvalidCell(t *testing.T) {
+	_, err := NewBatteryBank("12x4")
+	var cellErr *InvalidCellError
+	if !errors.As(err, &cellErr) || cellErr.Index != 2 || cellErr.Cell != 'x' {
+		t.Errorf("got %v, want an invalid cell 'x' at index 2", err)
+	}
+}
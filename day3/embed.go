@@ -0,0 +1,16 @@
+//go:build embedinput
+
+package day3
+
+import (
+	_ "embed"
+
+	"advent_of_code/input"
+)
+
+//go:embed input.txt
+var rawInput string
+
+func init() {
+	input.RegisterDefault(3, rawInput)
+}
@@ -0,0 +1,188 @@
+package day3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Objective chooses cellCount cells of a bank and returns their indexes in ascending order.
+type Objective interface {
+	Select(b BatteryBank, cellCount int) ([]int, error)
+}
+
+// MaxJoltage maximizes the joltage, i.e. the number formed by the selected digits. The cells of
+// Required are always selected and those of Forbidden never.
+type MaxJoltage struct {
+	Required  []int
+	Forbidden []int
+}
+
+// MinJoltage minimizes the joltage.
+type MinJoltage struct{}
+
+// BudgetMaxJoltage maximizes the joltage of the cells whose digits sum to at most Budget.
+type BudgetMaxJoltage struct {
+	Budget int
+}
+
+// MaxDigitSum maximizes the sum of the selected digits instead of the number they form.
+type MaxDigitSum struct{}
+
+var errNoSelection = errors.New("no selection satisfies the objective")
+
+// Select turns on the cells chosen by the objective and returns their indexes.
+func (b *BatteryBank) Select(objective Objective, cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+
+	selection, err := objective.Select(*b, cellCount)
+	if err != nil {
+		return nil, err
+	}
+	b.CellIndexesToTurnOn = slices.Clone(selection)
+	return selection, nil
+}
+
+func (o MaxJoltage) Select(b BatteryBank, cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+	if len(o.Required) == 0 && len(o.Forbidden) == 0 {
+		selection, err := b.MaxSelection(cellCount)
+		return slices.Clone(selection), err
+	}
+
+	n := b.Len()
+	required := make([]bool, n)
+	forbidden := make([]bool, n)
+	for _, i := range o.Required {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("required cell %d is not in the bank", i)
+		}
+		required[i] = true
+	}
+	for _, i := range o.Forbidden {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("forbidden cell %d is not in the bank", i)
+		}
+		if required[i] {
+			return nil, fmt.Errorf("cell %d is both required and forbidden", i)
+		}
+		forbidden[i] = true
+	}
+
+	// Taking the first of equal digits, as greedySelect does, can be wrong here: it may use up a
+	// cell that a later required cell with the same digit needs. So the best selection of r cells
+	// of every suffix is built from the right instead, which takes O(n * cellCount^2).
+	type choice struct {
+		digits  []byte
+		indexes []int
+	}
+	// best[r] is the best choice of r cells of the current suffix, nil if there is none
+	best := make([]*choice, cellCount+1)
+	best[0] = &choice{}
+	for i := n - 1; i >= 0; i-- {
+		next := make([]*choice, cellCount+1)
+		for r := 0; r <= cellCount; r++ {
+			if r > 0 && !forbidden[i] && best[r-1] != nil {
+				next[r] = &choice{
+					digits:  append([]byte{b.CellVoltate[i][0]}, best[r-1].digits...),
+					indexes: append([]int{i}, best[r-1].indexes...),
+				}
+			}
+			if !required[i] && best[r] != nil && (next[r] == nil || string(best[r].digits) > string(next[r].digits)) {
+				next[r] = best[r]
+			}
+		}
+		best = next
+	}
+
+	if best[cellCount] == nil {
+		return nil, errNoSelection
+	}
+	return best[cellCount].indexes, nil
+}
+
+func (MinJoltage) Select(b BatteryBank, cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+	return greedySelect(b, cellCount, lower, func(_, index, remaining, _ int) bool {
+		return b.Len()-index-1 >= remaining
+	})
+}
+
+func (o BudgetMaxJoltage) Select(b BatteryBank, cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+	n := b.Len()
+	// minSum[i][r] is the smallest digit sum of r cells at index i and later
+	minSum := make([][]int, n+1)
+	for i := n; i >= 0; i-- {
+		minSum[i] = make([]int, cellCount+1)
+		for r := 1; r <= cellCount; r++ {
+			minSum[i][r] = math.MaxInt / 2
+			if i < n {
+				minSum[i][r] = min(minSum[i+1][r], digit(b, i)+minSum[i+1][r-1])
+			}
+		}
+	}
+
+	return greedySelect(b, cellCount, higher, func(_, index, remaining, digitSum int) bool {
+		return digitSum+minSum[index+1][remaining] <= o.Budget
+	})
+}
+
+func (MaxDigitSum) Select(b BatteryBank, cellCount int) ([]int, error) {
+	if err := b.checkCellCount(cellCount); err != nil {
+		return nil, err
+	}
+	// the highest digits, the earliest first among equal ones
+	order := make([]int, b.Len())
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return digit(b, j) - digit(b, i) })
+
+	selection := slices.Clone(order[:cellCount])
+	slices.Sort(selection)
+	return selection, nil
+}
+
+func higher(a, b int) bool { return a > b }
+func lower(a, b int) bool  { return a < b }
+
+func digit(b BatteryBank, i int) int {
+	return int(b.CellVoltate[i][0] - '0')
+}
+
+// greedySelect picks cellCount cells from left to right. Every step takes the first cell with the
+// best digit among the cells after the previous one that feasible accepts. feasible is passed the
+// first index of the step, the candidate, the number of cells still needed after it and the digit
+// sum including it, and reports whether the selection can still be completed.
+// As long as no cell is required, taking the first of equal cells leaves the most choices, so the
+// digits are the best possible in order from left to right, which makes the formed number optimal.
+func greedySelect(b BatteryBank, cellCount int, better func(a, b int) bool, feasible func(start, index, remaining, digitSum int) bool) ([]int, error) {
+	selection := make([]int, 0, cellCount)
+	start, digitSum := 0, 0
+	for len(selection) < cellCount {
+		best := -1
+		for i := start; i < b.Len(); i++ {
+			if (best < 0 || better(digit(b, i), digit(b, best))) && feasible(start, i, cellCount-len(selection)-1, digitSum+digit(b, i)) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return nil, errNoSelection
+		}
+
+		selection = append(selection, best)
+		digitSum += digit(b, best)
+		start = best + 1
+	}
+	return selection, nil
+}
@@ -0,0 +1,153 @@
+package day3
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// bestExhaustive returns the best score of all selections of cellCount cells that allowed accepts,
+// ok is false if there is none.
+func bestExhaustive(bank string, cellCount int, allowed func(selection []int) bool, better func(a, b string) bool, score func(digits string) string) (best string, ok bool) {
+	for mask := 0; mask < 1<<len(bank); mask++ {
+		if bits.OnesCount(uint(mask)) != cellCount {
+			continue
+		}
+		selection := []int{}
+		digits := []byte{}
+		for i := range len(bank) {
+			if mask&(1<<i) != 0 {
+				selection = append(selection, i)
+				digits = append(digits, bank[i])
+			}
+		}
+		if !allowed(selection) {
+			continue
+		}
+		if s := score(string(digits)); !ok || better(s, best) {
+			best, ok = s, true
+		}
+	}
+	return best, ok
+}
+
+func digitSum(digits string) int {
+	sum := 0
+	for _, d := range digits {
+		sum += int(d - '0')
+	}
+	return sum
+}
+
+func TestObjectivesMatchExhaustiveSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	greater := func(a, b string) bool { return a > b }
+	smaller := func(a, b string) bool { return a < b }
+	concat := func(digits string) string { return digits }
+	// digit sums as fixed width strings, so they compare like numbers
+	sumScore := func(digits string) string { return fmt.Sprintf("%03d", digitSum(digits)) }
+	unconstrained := func([]int) bool { return true }
+
+	for range 300 {
+		digits := make([]byte, 1+rng.Intn(10))
+		for i := range digits {
+			digits[i] = byte('0' + rng.Intn(1+rng.Intn(10)))
+		}
+		bank := string(digits)
+		b, err := NewBatteryBank(bank)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		required := []int{}
+		forbidden := []int{}
+		for i := range bank {
+			switch rng.Intn(6) {
+			case 0:
+				required = append(required, i)
+			case 1:
+				forbidden = append(forbidden, i)
+			}
+		}
+		budget := rng.Intn(9 * len(bank))
+
+		for cellCount := 1; cellCount <= len(bank); cellCount++ {
+			for _, tt := range []struct {
+				objective Objective
+				allowed   func([]int) bool
+				better    func(a, b string) bool
+				score     func(string) string
+			}{
+				{objective: MaxJoltage{}, allowed: unconstrained, better: greater, score: concat},
+				{objective: MinJoltage{}, allowed: unconstrained, better: smaller, score: concat},
+				{objective: MaxDigitSum{}, allowed: unconstrained, better: greater, score: sumScore},
+				{objective: MaxJoltage{Required: required, Forbidden: forbidden}, better: greater, score: concat, allowed: func(selection []int) bool {
+					for _, i := range required {
+						if !slices.Contains(selection, i) {
+							return false
+						}
+					}
+					for _, i := range forbidden {
+						if slices.Contains(selection, i) {
+							return false
+						}
+					}
+					return true
+				}},
+				{objective: BudgetMaxJoltage{Budget: budget}, better: greater, score: concat, allowed: func(selection []int) bool {
+					return digitSum(b.Digits(selection)) <= budget
+				}},
+			} {
+				want, ok := bestExhaustive(bank, cellCount, tt.allowed, tt.better, tt.score)
+				got, err := b.Select(tt.objective, cellCount)
+				if !ok {
+					if !errors.Is(err, errNoSelection) {
+						t.Fatalf("%s, %d cells, %+v: got %v, %v, want no selection", bank, cellCount, tt.objective, got, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s, %d cells, %+v: %v", bank, cellCount, tt.objective, err)
+				}
+				if len(got) != cellCount || !slices.IsSorted(got) || !tt.allowed(got) || tt.score(b.Digits(got)) != want {
+					t.Fatalf("%s, %d cells, %+v: got %v (%s), want %s", bank, cellCount, tt.objective, got, b.Digits(got), want)
+				}
+				if !slices.Equal(b.CellIndexesToTurnOn, got) {
+					t.Fatalf("%s: selected cells are not turned on", bank)
+				}
+			}
+		}
+	}
+}
+
+func TestMaxJoltageConstraintErrors(t *testing.T) {
+	b, err := NewBatteryBank("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range []MaxJoltage{{Required: []int{5}}, {Forbidden: []int{-1}}, {Required: []int{1}, Forbidden: []int{1}}} {
+		if _, err := b.Select(o, 2); err == nil {
+			t.Errorf("%+v: expected an error", o)
+		}
+	}
+	if _, err := b.Select(MaxJoltage{Required: []int{0, 1, 2}}, 2); !errors.Is(err, errNoSelection) {
+		t.Errorf("more required cells than selected: got %v, want %v", err, errNoSelection)
+	}
+}
+
+func TestObjectivesCheckCellCount(t *testing.T) {
+	b, err := NewBatteryBank("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range []Objective{MaxJoltage{}, MaxJoltage{Required: []int{1}}, MinJoltage{}, BudgetMaxJoltage{Budget: 45}, MaxDigitSum{}} {
+		for _, cellCount := range []int{-1, b.Len() + 1} {
+			if got, err := o.Select(b, cellCount); err == nil {
+				t.Errorf("%+v with %d cells: got %v, want an error", o, cellCount, got)
+			}
+		}
+	}
+}
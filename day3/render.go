@@ -0,0 +1,216 @@
+package day3
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+)
+
+// RenderFormats lists the supported render formats.
+var RenderFormats = []string{"text", "ansi", "html", "svg"}
+
+// The colours of the selections, cycled if there are more selections than colours.
+var (
+	ansiPalette = []string{CONSOLE_RED, CONSOLE_GREEN, CONSOLE_YELLOW, CONSOLE_BLUE, CONSOLE_MAGENTA, CONSOLE_CYAN}
+	cssPalette  = []string{"#d70000", "#008700", "#af8700", "#005fd7", "#af00af", "#00afaf"}
+)
+
+// DetectFormat returns "ansi" if f is a terminal and NO_COLOR is not set, "text" otherwise.
+func DetectFormat(f *os.File) string {
+	if os.Getenv("NO_COLOR") != "" {
+		return "text"
+	}
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return "text"
+	}
+	return "ansi"
+}
+
+// Renderer writes banks with the max joltage selections of one or more cell counts highlighted.
+//
+// In text every bank is followed by a line marking the cells of each selection, ansi and html
+// colour the cells and svg draws them as an image. A cell of several selections gets the colour
+// of the first.
+type Renderer struct {
+	w      *bufio.Writer
+	format string
+}
+
+// NewRenderer returns a renderer writing to w in one of RenderFormats.
+func NewRenderer(w io.Writer, format string) (*Renderer, error) {
+	switch format {
+	case "text", "ansi", "html", "svg":
+		return &Renderer{w: bufio.NewWriter(w), format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown render format '%s', expected one of %v", format, RenderFormats)
+	}
+}
+
+// Render writes all banks, highlighting the max joltage selection of every cell count.
+func (r *Renderer) Render(banks []BatteryBank, cellCounts ...int) error {
+	selections := make([][][]int, len(banks))
+	for i := range banks {
+		selections[i] = make([][]int, len(cellCounts))
+		for j, cellCount := range cellCounts {
+			selection, err := banks[i].MaxSelection(cellCount)
+			if err != nil {
+				return fmt.Errorf("bank %d: %w", i+1, err)
+			}
+			selections[i][j] = selection
+		}
+	}
+
+	names := make([]string, len(cellCounts))
+	for i, cellCount := range cellCounts {
+		names[i] = fmt.Sprintf("%d cells", cellCount)
+	}
+
+	switch r.format {
+	case "text":
+		r.renderText(banks, selections, names)
+	case "ansi":
+		r.renderANSI(banks, selections, names)
+	case "html":
+		r.renderHTML(banks, selections, names)
+	case "svg":
+		r.renderSVG(banks, selections, names)
+	}
+	return r.w.Flush()
+}
+
+func (r *Renderer) renderText(banks []BatteryBank, selections [][][]int, names []string) {
+	for i, b := range banks {
+		r.w.WriteString(strings.Join(b.CellVoltate, ""))
+		r.w.WriteByte('\n')
+		for j, selection := range selections[i] {
+			marks := []byte(strings.Repeat(" ", b.Len()))
+			for _, index := range selection {
+				marks[index] = '^'
+			}
+			fmt.Fprintf(r.w, "%s  %s: %s\n", marks, names[j], b.Digits(selection))
+		}
+	}
+}
+
+func (r *Renderer) renderANSI(banks []BatteryBank, selections [][][]int, names []string) {
+	for j, name := range names {
+		if j > 0 {
+			r.w.WriteByte(' ')
+		}
+		r.w.WriteString(ansiPalette[j%len(ansiPalette)] + name + CONSOLE_RESET)
+	}
+	r.w.WriteByte('\n')
+	for i, b := range banks {
+		writeANSI(r.w, b, cellLayers(b.Len(), selections[i]))
+		r.w.WriteByte('\n')
+	}
+}
+
+func (r *Renderer) renderHTML(banks []BatteryBank, selections [][][]int, names []string) {
+	r.w.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Battery banks</title>\n<style>\n")
+	for j := range names {
+		fmt.Fprintf(r.w, ".s%d { color: %s; font-weight: bold; }\n", j, cssPalette[j%len(cssPalette)])
+	}
+	r.w.WriteString("</style>\n</head>\n<body>\n<p>")
+	for j, name := range names {
+		if j > 0 {
+			r.w.WriteByte(' ')
+		}
+		fmt.Fprintf(r.w, "<span class=\"s%d\">%s</span>", j, html.EscapeString(name))
+	}
+	r.w.WriteString("</p>\n<pre>\n")
+	for i, b := range banks {
+		forEachRun(b, cellLayers(b.Len(), selections[i]), func(digits string, layer int) {
+			if layer < 0 {
+				r.w.WriteString(digits)
+			} else {
+				fmt.Fprintf(r.w, "<span class=\"s%d\">%s</span>", layer, digits)
+			}
+		})
+		r.w.WriteByte('\n')
+	}
+	r.w.WriteString("</pre>\n</body>\n</html>\n")
+}
+
+// The size of a cell in the svg, in pixels.
+const (
+	svgCellWidth  = 9
+	svgLineHeight = 18
+)
+
+func (r *Renderer) renderSVG(banks []BatteryBank, selections [][][]int, names []string) {
+	width := 0
+	for _, b := range banks {
+		width = max(width, b.Len())
+	}
+	// the legend takes the first line
+	fmt.Fprintf(r.w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"15\">\n",
+		(width+2)*svgCellWidth, (len(banks)+2)*svgLineHeight)
+	r.w.WriteString("<rect width=\"100%\" height=\"100%\" fill=\"white\"/>\n")
+
+	fmt.Fprintf(r.w, "<text x=\"%d\" y=\"%d\">", svgCellWidth, svgLineHeight)
+	for j, name := range names {
+		if j > 0 {
+			r.w.WriteString("<tspan> </tspan>")
+		}
+		fmt.Fprintf(r.w, "<tspan fill=\"%s\" font-weight=\"bold\">%s</tspan>", cssPalette[j%len(cssPalette)], html.EscapeString(name))
+	}
+	r.w.WriteString("</text>\n")
+
+	for i, b := range banks {
+		fmt.Fprintf(r.w, "<text x=\"%d\" y=\"%d\">", svgCellWidth, (i+2)*svgLineHeight)
+		forEachRun(b, cellLayers(b.Len(), selections[i]), func(digits string, layer int) {
+			if layer < 0 {
+				fmt.Fprintf(r.w, "<tspan fill=\"gray\">%s</tspan>", digits)
+			} else {
+				fmt.Fprintf(r.w, "<tspan fill=\"%s\" font-weight=\"bold\">%s</tspan>", cssPalette[layer%len(cssPalette)], digits)
+			}
+		})
+		r.w.WriteString("</text>\n")
+	}
+	r.w.WriteString("</svg>\n")
+}
+
+// writeANSI writes the cells of b, coloured by their layer.
+func writeANSI(w io.StringWriter, b BatteryBank, layers []int) {
+	forEachRun(b, layers, func(digits string, layer int) {
+		if layer < 0 {
+			w.WriteString(digits)
+		} else {
+			w.WriteString(ansiPalette[layer%len(ansiPalette)] + digits + CONSOLE_RESET)
+		}
+	})
+}
+
+// cellLayers returns for every cell the index of the first selection containing it, -1 if there is
+// none. Indexes outside the bank are ignored.
+func cellLayers(n int, selections [][]int) []int {
+	layers := make([]int, n)
+	for i := range layers {
+		layers[i] = -1
+	}
+	for layer := len(selections) - 1; layer >= 0; layer-- {
+		for _, index := range selections[layer] {
+			if index >= 0 && index < n {
+				layers[index] = layer
+			}
+		}
+	}
+	return layers
+}
+
+// forEachRun calls fn with every run of adjacent cells of the same layer, from left to right.
+func forEachRun(b BatteryBank, layers []int, fn func(digits string, layer int)) {
+	for start := 0; start < len(layers); {
+		end := start + 1
+		for end < len(layers) && layers[end] == layers[start] {
+			end++
+		}
+		fn(strings.Join(b.CellVoltate[start:end], ""), layers[start])
+		start = end
+	}
+}
@@ -0,0 +1,146 @@
+package day3
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, format string, rawBanks string, cellCounts ...int) string {
+	t.Helper()
+	banks, err := ParseBatteryBanks(rawBanks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	r, err := NewRenderer(&out, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Render(banks, cellCounts...); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestRenderText(t *testing.T) {
+	got := render(t, "text", "1892\n35", 1, 2)
+	want := "1892\n" +
+		"  ^   1 cells: 9\n" +
+		"  ^^  2 cells: 92\n" +
+		"35\n" +
+		" ^  1 cells: 5\n" +
+		"^^  2 cells: 35\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	// the cell of both selections has the colour of the first
+	got := render(t, "ansi", "1892", 1, 2)
+	want := CONSOLE_RED + "1 cells" + CONSOLE_RESET + " " + CONSOLE_GREEN + "2 cells" + CONSOLE_RESET + "\n" +
+		"18" + CONSOLE_RED + "9" + CONSOLE_RESET + CONSOLE_GREEN + "2" + CONSOLE_RESET + "\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	got := render(t, "html", "1892\n35", 2)
+	for _, want := range []string{
+		".s0 { color: #d70000; font-weight: bold; }",
+		"<span class=\"s0\">2 cells</span>",
+		"18<span class=\"s0\">92</span>\n<span class=\"s0\">35</span>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	got := render(t, "svg", "1892\n35", 1, 2)
+
+	// the svg must be well-formed, with a line for the legend and every bank
+	decoder := xml.NewDecoder(strings.NewReader(got))
+	lines := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid svg: %v\n%s", err, got)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "text" {
+			lines++
+		}
+	}
+	if lines != 3 {
+		t.Errorf("got %d lines of text, want 3", lines)
+	}
+	if want := "<tspan fill=\"#d70000\" font-weight=\"bold\">9</tspan><tspan fill=\"#008700\" font-weight=\"bold\">2</tspan>"; !strings.Contains(got, want) {
+		t.Errorf("missing %q in\n%s", want, got)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	if _, err := NewRenderer(io.Discard, "png"); err == nil {
+		t.Error("NewRenderer(png): expected an error")
+	}
+
+	banks, err := ParseBatteryBanks("1892\n35")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRenderer(io.Discard, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Render(banks, 3); err == nil || !strings.HasPrefix(err.Error(), "bank 2:") {
+		t.Errorf("3 cells of a bank of 2: got %v, want an error for bank 2", err)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got := DetectFormat(f); got != "text" {
+		t.Errorf("DetectFormat(file) = %s, want text", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := DetectFormat(os.Stdout); got != "text" {
+		t.Errorf("DetectFormat with NO_COLOR = %s, want text", got)
+	}
+}
+
+func TestStringIsPlain(t *testing.T) {
+	b, err := NewBatteryBank("1892")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.GetMaxJoltage(2); err != nil {
+		t.Fatal(err)
+	}
+	// the cells that are turned on are not highlighted
+	if got := b.String(); got != "1892" {
+		t.Errorf("String() = %q, want 1892", got)
+	}
+}
+
+func TestCellLayersIgnoresIndexesOutsideTheBank(t *testing.T) {
+	got := cellLayers(4, [][]int{{-1, 2, 4}, {1, 2, 9}})
+	if want := []int{-1, 1, 0, -1}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package day4
+
+import (
+	"bufio"
+	"strings"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+const (
+	PAPER_ROLE = '@'
+	EMPTY_CELL = '.'
+)
+
+// Diagram holds one row per line, a cell is 1 if it holds a paper role and 0 otherwise.
+type Diagram [][]uint8
+
+func parseDiagram(rawDiagram string) (Diagram, error) {
+	scanner := bufio.NewScanner(strings.NewReader(rawDiagram))
+	diagram := Diagram{}
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// all rows must have the same width as the first one
+		if len(diagram) > 0 && len(line) != len(diagram[0]) {
+			return nil, input.Errorf(lineNumber, min(len(line), len(diagram[0]))+1, "inconsistent row width: expected %d, got %d", len(diagram[0]), len(line))
+		}
+
+		row := make([]uint8, len(line))
+		for i, ch := range line {
+			switch ch {
+			case PAPER_ROLE:
+				row[i] = 1
+			case EMPTY_CELL:
+			default:
+				return nil, input.Errorf(lineNumber, i+1, "invalid cell '%c'", ch)
+			}
+		}
+		diagram = append(diagram, row)
+	}
+	return diagram, scanner.Err()
+}
+
+// evaluateCell evaluates the cell at (row, col); it returns true if the sum of adjacent cells is < 4
+func (diagram Diagram) evaluateCell(row, col int) bool {
+	sum := 9
+	for r := max(0, row-1); r <= min(len(diagram)-1, row+1); r++ {
+		for c := max(0, col-1); c <= min(len(diagram[0])-1, col+1); c++ {
+			sum -= int(diagram[r][c])
+		}
+	}
+	return diagram[row][col] == 1 && sum > 4 // additional check to ensure we are evaluating a paper cell (normally handled in the caller)
+}
+
+func (diagram Diagram) countMovableCells() int {
+	count := 0
+	for r, row := range diagram {
+		for c := range row {
+			if diagram[r][c] == 1 && diagram.evaluateCell(r, c) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// countAndMoveCells repeatedly moves all movable cells; it works on a copy and leaves the diagram untouched.
+func (d Diagram) countAndMoveCells() int {
+	count := 0
+
+	diagram := make(Diagram, len(d))
+	for r := range d {
+		diagram[r] = make([]uint8, len(d[r]))
+		copy(diagram[r], d[r])
+	}
+
+	for {
+		// Copy state
+		oldCount := count
+		for r, row := range diagram {
+			for c := range row {
+				if diagram[r][c] == 1 && diagram.evaluateCell(r, c) {
+					diagram[r][c] = 0
+					count++
+				}
+			}
+		}
+
+		if count == oldCount {
+			break
+		}
+	}
+
+	return count
+}
+
+func init() {
+	solver.Register(4, func() solver.Solver { return &Solver{} })
+}
+
+// Solver counts the paper cells that can be moved at once (part one) and in total (part two).
+type Solver struct {
+	diagram Diagram
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.diagram, err = parseDiagram(input)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return s.diagram.countMovableCells(), nil
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return s.diagram.countAndMoveCells(), nil
+}
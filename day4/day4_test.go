@@ -0,0 +1,44 @@
+package day4
+
+import (
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = `..@@.@@@@.
+@@@.@.@.@@
+@@@@@.@.@@
+@.@@@@..@.
+@@.@@@@.@@
+.@@@@@@@.@
+.@.@.@.@@@
+@.@@@.@@@@
+.@@@@@@@@.
+@.@.@@@.@.`
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 4, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 13, PartTwo: 43},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 13, PartTwo: 43},
+		{Name: "single cell", Input: "@", PartOne: 1, PartTwo: 1},
+		{Name: "no paper", Input: "...\n...", PartOne: 0, PartTwo: 0},
+		{Name: "full block", Input: "@@@\n@@@\n@@@", PartOne: 4, PartTwo: 9},
+		{Name: "single row", Input: "@@@@@", PartOne: 5, PartTwo: 5},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 4, []solvertest.ErrorCase{
+		{Name: "invalid cell", Input: "..@\n.#.", Line: 2, Column: 2},
+		{Name: "inconsistent width", Input: "..@\n.@\n...", Line: 2, Column: 3},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 4)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 4, example)
+}
@@ -0,0 +1,186 @@
+package day5
+
+import (
+	"bufio"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+type databaseEntry struct {
+	id      int
+	isFresh bool
+}
+
+// FreshDatabase stores the fresh id ranges as sorted markers, each marker starts a fresh or non-fresh span.
+type FreshDatabase struct {
+	entries []databaseEntry
+}
+
+// addRange marks the ids startId..endId (inclusive) as fresh; startId must not be greater than endId.
+func (db *FreshDatabase) addRange(startId, endId int) {
+	database := db.entries
+
+	endId += 1 // make endId exclusive
+
+	// Find first entry >= startId
+	idxStart := sort.Search(len(database), func(i int) bool {
+		return database[i].id >= startId
+	})
+
+	// Find first entry >= endId
+	idxEnd := sort.Search(len(database), func(i int) bool {
+		return database[i].id >= endId
+	})
+
+	// If idxStart > 0, we look at the previous entry.
+	isFreshBefore := idxStart > 0 && database[idxStart-1].isFresh
+
+	isFreshAfter := false
+	if idxEnd < len(database) && database[idxEnd].id == endId {
+		// exact match
+		isFreshAfter = database[idxEnd].isFresh
+	} else if idxEnd > 0 {
+		// look at previous entry
+		isFreshAfter = database[idxEnd-1].isFresh
+	}
+
+	var newMarkers []databaseEntry
+
+	// add fresh market if the state before is NOT Fresh
+	if !isFreshBefore {
+		newMarkers = append(newMarkers, databaseEntry{id: startId, isFresh: true})
+	}
+
+	// add un-fresh marker if the state after is NOT Fresh
+	if !isFreshAfter {
+		newMarkers = append(newMarkers, databaseEntry{id: endId, isFresh: false})
+	}
+
+	// We remove everything from idxStart up to idxEnd. Add one if we have an exact match at endId.
+	replaceUntil := idxEnd
+	if idxEnd < len(database) && database[idxEnd].id == endId {
+		replaceUntil++
+	}
+
+	db.entries = slices.Replace(database, idxStart, replaceUntil, newMarkers...)
+}
+
+// setupDatabase reads the fresh id ranges up to the first empty line; it also returns the number of lines read.
+func setupDatabase(scanner *bufio.Scanner) (db *FreshDatabase, lineNumber int, err error) {
+	db = &FreshDatabase{}
+
+	// add database entries until we find an empty line
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+
+		// entry line format: "int-int"
+		parts := strings.Split(line, "-")
+		if len(parts) != 2 {
+			return nil, lineNumber, input.Errorf(lineNumber, 1, "invalid range '%s': expected 'start-end'", line)
+		}
+
+		startId, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, lineNumber, input.Errorf(lineNumber, 1, "invalid start id: %w", err)
+		}
+
+		endId, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, lineNumber, input.Errorf(lineNumber, len(parts[0])+2, "invalid end id: %w", err)
+		}
+
+		if startId > endId {
+			return nil, lineNumber, input.Errorf(lineNumber, 1, "start id %d is greater than end id %d", startId, endId)
+		}
+
+		db.addRange(startId, endId)
+	}
+	return db, lineNumber, scanner.Err()
+}
+
+// parseIngredients reads the remaining lines as ingredient ids, lineNumber is the number of lines already read.
+func parseIngredients(scanner *bufio.Scanner, lineNumber int) (ids []int, err error) {
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		itemId, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, input.Errorf(lineNumber, 1, "invalid item id: %w", err)
+		}
+		ids = append(ids, itemId)
+	}
+	return ids, scanner.Err()
+}
+
+func (db *FreshDatabase) countFreshIngriedients(ids []int) int {
+	database := db.entries
+	count := 0
+	for _, itemId := range ids {
+		// binary search in database
+		index := sort.Search(len(database), func(i int) bool {
+			return database[i].id > itemId
+		})
+
+		if index > 0 && database[index-1].isFresh {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (db *FreshDatabase) countTotalFreshIds() int {
+	database := db.entries
+	count := 0
+	for i := 0; i < len(database)-1; i++ {
+		if database[i].isFresh {
+			count += database[i+1].id - database[i].id
+		}
+	}
+	return count
+}
+
+func init() {
+	solver.Register(5, func() solver.Solver { return &Solver{} })
+}
+
+// Solver counts the fresh ingredients (part one) and all ids considered fresh by the database (part two).
+type Solver struct {
+	database    *FreshDatabase
+	ingredients []int
+}
+
+func (s *Solver) Parse(rawInput string) error {
+	scanner := bufio.NewScanner(strings.NewReader(rawInput))
+
+	database, lineNumber, err := setupDatabase(scanner)
+	if err != nil {
+		return err
+	}
+	s.database = database
+
+	// the remaining lines are the available ingredients
+	s.ingredients, err = parseIngredients(scanner, lineNumber)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return s.database.countFreshIngriedients(s.ingredients), nil
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return s.database.countTotalFreshIds(), nil
+}
@@ -0,0 +1,99 @@
+package day5
+
+import (
+	"slices"
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = `3-5
+10-14
+16-20
+12-18
+
+1
+5
+8
+11
+17
+32`
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 5, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 3, PartTwo: 14},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 3, PartTwo: 14},
+		{Name: "overlapping ranges", Input: "1-3\n2-5\n\n0\n1\n5\n6", PartOne: 2, PartTwo: 5},
+		{Name: "adjacent ranges", Input: "1-2\n3-4\n\n2\n3", PartOne: 2, PartTwo: 4},
+		{Name: "nested ranges", Input: "1-10\n3-4\n\n4", PartOne: 1, PartTwo: 10},
+		{Name: "single id range", Input: "7-7\n\n6\n7\n8", PartOne: 1, PartTwo: 1},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 5, []solvertest.ErrorCase{
+		{Name: "invalid range", Input: "1-3\n4\n\n1", Line: 2, Column: 1},
+		{Name: "invalid end id", Input: "1-3\n4-x\n\n1", Line: 2, Column: 3},
+		{Name: "reversed range", Input: "5-3\n\n1", Line: 1, Column: 1},
+		{Name: "invalid item id", Input: "1-3\n\n1\nfoo", Line: 4, Column: 1},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 5)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 5, example)
+}
+
+func TestAddRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges [][2]int
+		want   []databaseEntry
+	}{
+		{
+			name:   "single range",
+			ranges: [][2]int{{3, 5}},
+			want:   []databaseEntry{{3, true}, {6, false}},
+		},
+		{
+			name:   "disjoint ranges",
+			ranges: [][2]int{{10, 12}, {1, 2}},
+			want:   []databaseEntry{{1, true}, {3, false}, {10, true}, {13, false}},
+		},
+		{
+			name:   "merge overlapping ranges",
+			ranges: [][2]int{{1, 5}, {4, 8}},
+			want:   []databaseEntry{{1, true}, {9, false}},
+		},
+		{
+			name:   "merge adjacent ranges",
+			ranges: [][2]int{{1, 2}, {3, 4}},
+			want:   []databaseEntry{{1, true}, {5, false}},
+		},
+		{
+			name:   "bridge ranges",
+			ranges: [][2]int{{1, 2}, {6, 7}, {2, 6}},
+			want:   []databaseEntry{{1, true}, {8, false}},
+		},
+		{
+			name:   "contained range",
+			ranges: [][2]int{{1, 10}, {3, 4}},
+			want:   []databaseEntry{{1, true}, {11, false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &FreshDatabase{}
+			for _, r := range tt.ranges {
+				db.addRange(r[0], r[1])
+			}
+			if !slices.Equal(db.entries, tt.want) {
+				t.Errorf("got %v, want %v", db.entries, tt.want)
+			}
+		})
+	}
+}
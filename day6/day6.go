@@ -0,0 +1,204 @@
+package day6
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+type Operation struct {
+	Offset int
+	Length int
+	Fn     func(int, int) int
+}
+
+// Worksheet holds the raw number lines followed by the operations line, and the operations parsed from it.
+type Worksheet struct {
+	rawLines   []string
+	operations []Operation
+}
+
+func parseWorksheet(rawInput string) (*Worksheet, error) {
+	scanner := bufio.NewScanner(strings.NewReader(rawInput))
+
+	// read all lines
+	rawLines := []string{}
+	for scanner.Scan() {
+		rawLines = append(rawLines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rawLines) < 2 {
+		return nil, input.Errorf(len(rawLines)+1, 1, "expected at least one number line followed by the operations line")
+	}
+
+	// parse math operations
+	mathOperationsLine := rawLines[len(rawLines)-1]
+	operationsLineNumber := len(rawLines)
+
+	// every line must be as wide as the operations line and contain only digits and spaces
+	for i, line := range rawLines[:len(rawLines)-1] {
+		if len(line) != len(mathOperationsLine) {
+			return nil, input.Errorf(i+1, min(len(line), len(mathOperationsLine))+1, "inconsistent line width: expected %d, got %d", len(mathOperationsLine), len(line))
+		}
+		if j := strings.IndexFunc(line, func(r rune) bool { return r != ' ' && (r < '0' || r > '9') }); j >= 0 {
+			return nil, input.Errorf(i+1, j+1, "invalid character '%c' in number line", line[j])
+		}
+	}
+
+	operations := []Operation{}
+	index := 0
+ParseOperations:
+	for index < len(mathOperationsLine) {
+		nextOperation := Operation{Offset: index, Length: 1}
+
+		// parse operation
+		switch mathOperationsLine[index] {
+		case '+':
+			nextOperation.Fn = func(a, b int) int { return a + b }
+		case '*':
+			nextOperation.Fn = func(a, b int) int { return a * b }
+		default:
+			return nil, input.Errorf(operationsLineNumber, index+1, "unknown operation: '%c'", mathOperationsLine[index])
+		}
+
+		// find next operator or end of line
+		for {
+			if index+nextOperation.Length+1 >= len(mathOperationsLine) {
+				nextOperation.Length = len(mathOperationsLine) - index
+				operations = append(operations, nextOperation)
+				break ParseOperations
+			}
+			next := mathOperationsLine[index+nextOperation.Length+1]
+			if next == '+' || next == '*' {
+				index += nextOperation.Length + 1
+				operations = append(operations, nextOperation)
+				continue ParseOperations
+			}
+			if next != ' ' {
+				return nil, input.Errorf(operationsLineNumber, index+nextOperation.Length+2, "unknown operation: '%c'", next)
+			}
+			nextOperation.Length++
+		}
+	}
+
+	worksheet := &Worksheet{rawLines: rawLines, operations: operations}
+	if err := worksheet.validateNumbers(); err != nil {
+		return nil, err
+	}
+	return worksheet, nil
+}
+
+// validateNumbers verifies that every operation has a number in each row (part one) and each column (part two).
+func (w *Worksheet) validateNumbers() error {
+	rawLines := w.rawLines
+	for _, operation := range w.operations {
+		for i, line := range rawLines[:len(rawLines)-1] {
+			if strings.TrimSpace(line[operation.Offset:operation.Offset+operation.Length]) == "" {
+				return input.Errorf(i+1, operation.Offset+1, "missing number")
+			}
+		}
+
+	ColumnSearch:
+		for column := operation.Offset; column < operation.Offset+operation.Length; column++ {
+			for _, line := range rawLines[:len(rawLines)-1] {
+				if line[column] != ' ' {
+					continue ColumnSearch
+				}
+			}
+			return input.Errorf(1, column+1, "missing number in column")
+		}
+	}
+	return nil
+}
+
+func (w *Worksheet) partOne() (int, error) {
+	rawLines := w.rawLines
+	getNumber := func(index, offset, length int) (int, error) {
+		numStr := rawLines[index][offset : offset+length]
+		numStr = strings.TrimSpace(numStr)
+		num, err := strconv.Atoi(numStr)
+		if err != nil {
+			return 0, input.Errorf(index+1, offset+1, "invalid number: '%s'", numStr)
+		}
+		return num, nil
+	}
+
+	total := 0
+	for _, operation := range w.operations {
+		operationRes, err := getNumber(0, operation.Offset, operation.Length)
+		if err != nil {
+			return 0, err
+		}
+		for i := 1; i < len(rawLines)-1; i++ {
+			num, err := getNumber(i, operation.Offset, operation.Length)
+			if err != nil {
+				return 0, err
+			}
+			operationRes = operation.Fn(operationRes, num)
+		}
+		total += operationRes
+	}
+	return total, nil
+}
+
+func (w *Worksheet) partTwo() (int, error) {
+	rawLines := w.rawLines
+	// read number top to bottom at given index and offset
+	getNumber := func(offset, index int) (int, error) {
+		numStr := string(rawLines[0][offset+index])
+		for i := 1; i < len(rawLines)-1; i++ {
+			numStr += string(rawLines[i][offset+index])
+		}
+		numStr = strings.TrimSpace(numStr)
+		num, err := strconv.Atoi(numStr)
+		if err != nil {
+			return 0, input.Errorf(1, offset+index+1, "invalid number in column: '%s'", numStr)
+		}
+		return num, nil
+	}
+
+	total := 0
+	for _, operation := range w.operations {
+		operationRes, err := getNumber(operation.Offset, 0)
+		if err != nil {
+			return 0, err
+		}
+		for i := 1; i < operation.Length; i++ {
+			num, err := getNumber(operation.Offset, i)
+			if err != nil {
+				return 0, err
+			}
+			operationRes = operation.Fn(operationRes, num)
+		}
+		total += operationRes
+	}
+	return total, nil
+}
+
+func init() {
+	solver.Register(6, func() solver.Solver { return &Solver{} })
+}
+
+// Solver sums the worksheet problems read row by row (part one) and column by column (part two).
+type Solver struct {
+	worksheet *Worksheet
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.worksheet, err = parseWorksheet(input)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return s.worksheet.partOne()
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return s.worksheet.partTwo()
+}
@@ -0,0 +1,39 @@
+package day6
+
+import (
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = "123 328  51 64 \n" +
+	" 45 64  387 23 \n" +
+	"  6 98  215 314\n" +
+	"*   +   *   +  "
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 6, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 4277556, PartTwo: 3263827},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 4277556, PartTwo: 3263827},
+		{Name: "single problem", Input: "12\n34\n+ ", PartOne: 46, PartTwo: 37},
+		{Name: "uneven widths", Input: "12 3\n 4 5\n+  *", PartOne: 31, PartTwo: 60},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 6, []solvertest.ErrorCase{
+		{Name: "unknown operation", Input: "1 2\n3 4\n+ -", Line: 3, Column: 3},
+		{Name: "invalid digit", Input: "1 2\n3 x\n+ *", Line: 2, Column: 3},
+		{Name: "short line", Input: "1 2\n3\n+ *", Line: 2, Column: 2},
+		{Name: "missing number", Input: "1 2\n3  \n+ *", Line: 2, Column: 3},
+		{Name: "operations only", Input: "+ *", Line: 2, Column: 1},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 6)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 6, example)
+}
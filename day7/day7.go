@@ -0,0 +1,182 @@
+package day7
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+const (
+	START_SYMBOL    = "S"
+	SPLITTER_SYMBOL = "^"
+	EMPTY_SYMBOL    = "."
+	BEAM_SYMBOL     = "|"
+
+	EMPTY_RUNE    = '.'
+	SPLITTER_RUNE = '^'
+)
+
+// maxManifoldSize is the maximum number of lines and columns, the timeline cache indexes them with uint8
+const maxManifoldSize = 255
+
+// Manifold holds the lines of the manifold diagram, starting with the line of the start symbol.
+type Manifold struct {
+	lines []string
+}
+
+// parseManifold verifies that all lines are equally wide, hold only known symbols and that the
+// first line contains exactly one start symbol.
+func parseManifold(rawManifold string) (*Manifold, error) {
+	scanner := bufio.NewScanner(strings.NewReader(rawManifold))
+	m := &Manifold{}
+	width := -1
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if lineNumber > maxManifoldSize {
+			return nil, input.Errorf(lineNumber, 1, "manifold exceeds %d lines", maxManifoldSize)
+		}
+		if width == -1 {
+			width = len(line)
+			if width > maxManifoldSize {
+				return nil, input.Errorf(lineNumber, maxManifoldSize+1, "manifold exceeds %d columns", maxManifoldSize)
+			}
+			if strings.Count(line, START_SYMBOL) != 1 {
+				return nil, input.Errorf(lineNumber, 1, "expected exactly one '%s' in the first line, got %d", START_SYMBOL, strings.Count(line, START_SYMBOL))
+			}
+		} else if len(line) != width {
+			return nil, input.Errorf(lineNumber, min(len(line), width)+1, "lines must be of equal length (%d != %d)", width, len(line))
+		}
+
+		for i, ch := range line {
+			if ch != EMPTY_RUNE && ch != SPLITTER_RUNE && (lineNumber > 1 || string(ch) != START_SYMBOL) {
+				return nil, input.Errorf(lineNumber, i+1, "invalid symbol '%c'", ch)
+			}
+		}
+		m.lines = append(m.lines, line)
+	}
+	if width == -1 {
+		return nil, input.Errorf(1, 1, "empty manifold")
+	}
+	return m, scanner.Err()
+}
+
+func (m *Manifold) partOne() (total int, err error) {
+	// processBeam processes the current line based on the previous line and returns the updated current line.
+	processBeam := func(previousLineStr, currentLineStr string) (string, int, error) {
+		previousLineChars := strings.Split(previousLineStr, "")
+		currentLineChars := strings.Split(currentLineStr, "")
+
+		if len(previousLineChars) != len(currentLineChars) {
+			return "", 0, fmt.Errorf("lines must be of equal length (%d != %d)", len(previousLineChars), len(currentLineChars))
+		}
+
+		splitCount := 0
+		for i := range currentLineChars {
+			prevChar := previousLineChars[i]
+			currChar := currentLineChars[i]
+
+			if prevChar == BEAM_SYMBOL || prevChar == START_SYMBOL {
+				switch currChar {
+				case EMPTY_SYMBOL:
+					currentLineChars[i] = BEAM_SYMBOL
+				case SPLITTER_SYMBOL:
+					splitCount++
+					// Split beam to left and right
+					if i > 0 && currentLineChars[i-1] == EMPTY_SYMBOL {
+						currentLineChars[i-1] = BEAM_SYMBOL
+					}
+					if i < len(currentLineChars)-1 && currentLineChars[i+1] == EMPTY_SYMBOL {
+						currentLineChars[i+1] = BEAM_SYMBOL
+					}
+				}
+			}
+		}
+
+		return strings.Join(currentLineChars, ""), splitCount, nil
+	}
+
+	line := m.lines[0]
+	for i, nextLine := range m.lines[1:] {
+		var splitCount int
+		line, splitCount, err = processBeam(line, nextLine)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		total += splitCount
+	}
+
+	return total, nil
+}
+
+type keyType struct {
+	lineIndex uint8
+	beamIndex uint8
+}
+
+func eval(s [][]bool, cache map[keyType]int, lineIndex, beamIndex int) int {
+	// a beam that leaves the manifold at the bottom or at a side ends a timeline
+	if lineIndex >= len(s) || beamIndex < 0 || beamIndex >= len(s[lineIndex]) {
+		return 1
+	}
+
+	key := keyType{
+		lineIndex: uint8(lineIndex),
+		beamIndex: uint8(beamIndex),
+	}
+
+	if res, ok := cache[key]; ok {
+		return res
+	}
+
+	var res int
+	if s[lineIndex][beamIndex] {
+		res = eval(s, cache, lineIndex+1, beamIndex-1) + eval(s, cache, lineIndex+1, beamIndex+1)
+	} else {
+		res = eval(s, cache, lineIndex+1, beamIndex)
+	}
+
+	cache[key] = res
+
+	return res
+}
+
+func (m *Manifold) partTwo() int {
+	// look for start index
+	beamIndex := strings.Index(m.lines[0], START_SYMBOL)
+
+	splitters := [][]bool{}
+	for _, line := range m.lines[1:] {
+		splitterLine := make([]bool, len(line))
+		for i, elem := range line {
+			splitterLine[i] = (elem == SPLITTER_RUNE)
+		}
+		splitters = append(splitters, splitterLine)
+	}
+
+	return eval(splitters, make(map[keyType]int), 0, beamIndex)
+}
+
+func init() {
+	solver.Register(7, func() solver.Solver { return &Solver{} })
+}
+
+// Solver counts the beam splits (part one) and the possible beam timelines (part two).
+type Solver struct {
+	manifold *Manifold
+}
+
+func (s *Solver) Parse(input string) (err error) {
+	s.manifold, err = parseManifold(input)
+	return err
+}
+
+func (s *Solver) PartOne() (int, error) {
+	return s.manifold.partOne()
+}
+
+func (s *Solver) PartTwo() (int, error) {
+	return s.manifold.partTwo(), nil
+}
@@ -0,0 +1,53 @@
+package day7
+
+import (
+	"testing"
+
+	"advent_of_code/solver/solvertest"
+)
+
+const example = `.......S.......
+...............
+.......^.......
+...............
+......^.^......
+...............
+.....^.^.^.....
+...............
+....^.^...^....
+...............
+...^.^...^.^...
+...............
+..^...^.....^..
+...............
+.^.^.^.^.^...^.
+...............`
+
+func TestSolver(t *testing.T) {
+	solvertest.Run(t, 7, []solvertest.Case{
+		{Name: "example", Input: example, PartOne: 21, PartTwo: 40},
+		{Name: "trailing newline", Input: example + "\n", PartOne: 21, PartTwo: 40},
+		{Name: "no splitters", Input: "S\n.\n.", PartOne: 0, PartTwo: 1},
+		{Name: "single splitter", Input: ".S.\n.^.\n...", PartOne: 1, PartTwo: 2},
+		{Name: "merging beams", Input: "..S..\n..^..\n.^.^.\n.....", PartOne: 3, PartTwo: 4},
+		{Name: "splitter in the first column", Input: "S.\n^.\n..", PartOne: 1, PartTwo: 2},
+		{Name: "splitter in the last column", Input: ".S\n.^\n..", PartOne: 1, PartTwo: 2},
+		{Name: "beam leaving at a side", Input: "..S..\n..^..\n.^.^.\n^....\n.....", PartOne: 4, PartTwo: 5},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	solvertest.ParseErrors(t, 7, []solvertest.ErrorCase{
+		{Name: "missing start", Input: "...\n.^.", Line: 1, Column: 1},
+		{Name: "invalid symbol", Input: ".S.\n.^.\n.x.", Line: 3, Column: 2},
+		{Name: "unequal lines", Input: ".S.\n.^..", Line: 2, Column: 4},
+	})
+}
+
+func TestGolden(t *testing.T) {
+	solvertest.Golden(t, 7)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	solvertest.Benchmark(b, 7, example)
+}
@@ -0,0 +1,16 @@
+//go:build embedinput
+
+package day7
+
+import (
+	_ "embed"
+
+	"advent_of_code/input"
+)
+
+//go:embed input.txt
+var rawInput string
+
+func init() {
+	input.RegisterDefault(7, rawInput)
+}
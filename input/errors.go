@@ -0,0 +1,23 @@
+package input
+
+import "fmt"
+
+// ParseError reports malformed puzzle input at a 1-based line and column.
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+// Errorf returns a *ParseError for the given position; the message is formatted as with fmt.Errorf.
+func Errorf(line, column int, format string, a ...any) error {
+	return &ParseError{Line: line, Column: column, Err: fmt.Errorf(format, a...)}
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,86 @@
+// Package input loads puzzle inputs at runtime.
+//
+// An input is read from an explicit path, from stdin ("-") or from the inputs directory
+// (inputs/dayNN.txt, overridable with AOC_INPUTS). Binaries built with the "embedinput"
+// tag additionally carry each day's input.txt as a fallback.
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultDir is the inputs directory used if AOC_INPUTS is not set.
+const DefaultDir = "inputs"
+
+// Stdin is the path that selects standard input.
+const Stdin = "-"
+
+var defaults = map[int]string{}
+
+// RegisterDefault stores the embedded input of a day, which is used if no other input is found.
+func RegisterDefault(day int, input string) {
+	defaults[day] = input
+}
+
+// Dir returns the configured inputs directory.
+func Dir() string {
+	if dir := os.Getenv("AOC_INPUTS"); dir != "" {
+		return dir
+	}
+	return DefaultDir
+}
+
+// Path returns the path of a day's input inside dir, e.g. inputs/day05.txt.
+func Path(dir string, day int) string {
+	return filepath.Join(dir, fmt.Sprintf("day%02d.txt", day))
+}
+
+// Open returns a reader for the input of a day, so large inputs can be streamed instead of
+// loaded at once. It looks up the input like Load; the caller must close the reader.
+func Open(day int, path, dir string) (io.ReadCloser, error) {
+	switch path {
+	case Stdin:
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		path = Path(dir, day)
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			if input, ok := defaults[day]; ok {
+				return io.NopCloser(strings.NewReader(input)), nil
+			}
+			return nil, fmt.Errorf("no input for day %d: %s does not exist (pass a path, - for stdin, or build with -tags embedinput)", day, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading input: %w", err)
+		}
+		return f, nil
+	default:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading input: %w", err)
+		}
+		return f, nil
+	}
+}
+
+// Load returns the input of a day. If path is empty the input is looked up in dir,
+// falling back to the embedded default if the file does not exist.
+func Load(day int, path, dir string) (string, error) {
+	r, err := Open(day, path, dir)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	return string(data), nil
+}
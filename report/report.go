@@ -0,0 +1,133 @@
+// Package report writes solver results as text, JSON or CSV.
+//
+// The JSON and CSV records are stable: day, part, answer, parse and solve time in nanoseconds,
+// the input path if the input was given explicitly and what is known about the answer.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"advent_of_code/solver"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{"text", "json", "csv"}
+
+// Writer writes results in a specific format. Flush must be called after the last result.
+type Writer interface {
+	Write(r Record) error
+	Flush() error
+}
+
+// NewWriter returns a Writer for the given format.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w, records: []Record{}}, nil
+	case "csv":
+		return newCSVWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown format '%s', expected one of %v", format, Formats)
+	}
+}
+
+// Record is the machine-readable form of a result.
+type Record struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	Answer      int    `json:"answer"`
+	ParseTimeNs int64  `json:"parse_time_ns"`
+	SolveTimeNs int64  `json:"solve_time_ns"`
+	Input       string `json:"input,omitempty"`
+	// Check describes what is known about the answer, e.g. "matches known-correct answer".
+	Check string `json:"check,omitempty"`
+}
+
+// NewRecord returns the record of a result.
+func NewRecord(r solver.Result, inputPath string) Record {
+	return Record{
+		Day:         r.Day,
+		Part:        r.Part,
+		Answer:      r.Answer,
+		ParseTimeNs: r.ParseTime.Nanoseconds(),
+		SolveTimeNs: r.SolveTime.Nanoseconds(),
+		Input:       inputPath,
+	}
+}
+
+var partNames = map[int]string{1: "One", 2: "Two"}
+
+type textWriter struct {
+	w io.Writer
+}
+
+func (t *textWriter) Write(r Record) error {
+	prefix := ""
+	if r.Input != "" {
+		prefix = "[" + r.Input + "] "
+	}
+	suffix := ""
+	if r.Check != "" {
+		suffix = " - " + r.Check
+	}
+	_, err := fmt.Fprintf(t.w, "%sDay %d - Part %s: %d (parse time: %s, solve time: %s)%s\n", prefix, r.Day, partNames[r.Part], r.Answer,
+		time.Duration(r.ParseTimeNs), time.Duration(r.SolveTimeNs), suffix)
+	return err
+}
+
+func (t *textWriter) Flush() error {
+	return nil
+}
+
+// jsonWriter collects all records and writes them as a single array.
+type jsonWriter struct {
+	w       io.Writer
+	records []Record
+}
+
+func (j *jsonWriter) Write(r Record) error {
+	j.records = append(j.records, r)
+	return nil
+}
+
+func (j *jsonWriter) Flush() error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(j.records)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	if err := c.w.Write([]string{"day", "part", "answer", "parse_time_ns", "solve_time_ns", "input", "check"}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *csvWriter) Write(record Record) error {
+	return c.w.Write([]string{
+		strconv.Itoa(record.Day),
+		strconv.Itoa(record.Part),
+		strconv.Itoa(record.Answer),
+		strconv.FormatInt(record.ParseTimeNs, 10),
+		strconv.FormatInt(record.SolveTimeNs, 10),
+		record.Input,
+		record.Check,
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
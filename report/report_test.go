@@ -0,0 +1,60 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"advent_of_code/solver"
+)
+
+func TestWriter(t *testing.T) {
+	record := NewRecord(solver.Result{Day: 5, Part: 2, Answer: 14, ParseTime: 1500 * time.Nanosecond, SolveTime: 250 * time.Nanosecond}, "in.txt")
+	record.Check = "matches known-correct answer"
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want:   "[in.txt] Day 5 - Part Two: 14 (parse time: 1.5µs, solve time: 250ns) - matches known-correct answer\n",
+		},
+		{
+			format: "csv",
+			want:   "day,part,answer,parse_time_ns,solve_time_ns,input,check\n5,2,14,1500,250,in.txt,matches known-correct answer\n",
+		},
+		{
+			format: "json",
+			want: `[
+  {
+    "day": 5,
+    "part": 2,
+    "answer": 14,
+    "parse_time_ns": 1500,
+    "solve_time_ns": 250,
+    "input": "in.txt",
+    "check": "matches known-correct answer"
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		w, err := NewWriter(tt.format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package solver
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// Benchmark is a named benchmark of a day. Prepare does the work that is not measured and returns
+// the operation to run repeatedly.
+type Benchmark struct {
+	Name    string
+	Prepare func() (op func() error, err error)
+}
+
+// BenchResult is the outcome of a single benchmark.
+type BenchResult struct {
+	Day         int    `json:"day"`
+	Name        string `json:"name"`
+	N           int    `json:"n"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// Benchmarks returns the benchmarks of parsing and solving each part of a day.
+// The parts are solved repeatedly on a single parsed solver, the parsing is excluded from their timings.
+func Benchmarks(day int, input string) []Benchmark {
+	benchPart := func(part int) func() (func() error, error) {
+		return func() (func() error, error) {
+			s, err := parse(day, input)
+			if err != nil {
+				return nil, err
+			}
+			fn := s.PartOne
+			if part == 2 {
+				fn = s.PartTwo
+			}
+			return func() error {
+				_, err := fn()
+				return err
+			}, nil
+		}
+	}
+
+	return []Benchmark{
+		{Name: "parse", Prepare: func() (func() error, error) {
+			return func() error {
+				_, err := parse(day, input)
+				return err
+			}, nil
+		}},
+		{Name: "part1", Prepare: benchPart(1)},
+		{Name: "part2", Prepare: benchPart(2)},
+	}
+}
+
+func parse(day int, input string) (Solver, error) {
+	s, err := New(day)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Parse(input); err != nil {
+		return nil, fmt.Errorf("day %d: parsing input: %w", day, err)
+	}
+	return s, nil
+}
+
+// benchTime is how long Bench runs each benchmark at least, like go test's default -benchtime.
+const benchTime = time.Second
+
+// Bench runs all benchmarks of a day outside of go test.
+func Bench(day int, input string) ([]BenchResult, error) {
+	var results []BenchResult
+	for _, bm := range Benchmarks(day, input) {
+		op, err := bm.Prepare()
+		if err != nil {
+			return nil, err
+		}
+		r, err := measure(op)
+		if err != nil {
+			return nil, fmt.Errorf("day %d: benchmark %s: %w", day, bm.Name, err)
+		}
+		r.Day, r.Name = day, bm.Name
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// measure runs op n times, growing n until the runs take benchTime, and returns the cost of one run.
+func measure(op func() error) (BenchResult, error) {
+	for n := 1; ; {
+		runtime.GC()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for range n {
+			if err := op(); err != nil {
+				return BenchResult{}, err
+			}
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if elapsed >= benchTime || n >= 1e9 {
+			return BenchResult{
+				N:           n,
+				NsPerOp:     elapsed.Nanoseconds() / int64(n),
+				AllocsPerOp: int64(after.Mallocs-before.Mallocs) / int64(n),
+				BytesPerOp:  int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+			}, nil
+		}
+
+		// aim 20% past benchTime at the rate so far, growing by at most 100 times
+		next := 100 * n
+		if elapsed > 0 {
+			next = min(next, int(int64(n)*int64(benchTime)*6/5/elapsed.Nanoseconds()))
+		}
+		n = max(next, n+1)
+	}
+}
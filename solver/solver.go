@@ -0,0 +1,123 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Solver solves both parts of a single day's puzzle.
+type Solver interface {
+	// Parse reads the raw puzzle input; it is called once before either part is solved.
+	Parse(input string) error
+	// PartOne and PartTwo may be called repeatedly and must not change the parsed state.
+	PartOne() (int, error)
+	PartTwo() (int, error)
+}
+
+var solvers = map[int]func() Solver{}
+
+// Register makes a day available to the runner. newSolver is called for every run, so each run works on fresh state.
+func Register(day int, newSolver func() Solver) {
+	if _, ok := solvers[day]; ok {
+		panic(fmt.Sprintf("solver for day %d registered twice", day))
+	}
+	solvers[day] = newSolver
+}
+
+// New returns a fresh solver for the given day.
+func New(day int) (Solver, error) {
+	newSolver, ok := solvers[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for day %d", day)
+	}
+	return newSolver(), nil
+}
+
+// Days returns all registered days in ascending order.
+func Days() []int {
+	days := make([]int, 0, len(solvers))
+	for day := range solvers {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Result is the answer to a single part of a day.
+type Result struct {
+	Day    int
+	Part   int
+	Answer int
+	// ParseTime is the time spent parsing the input, it is shared by all parts of a run.
+	ParseTime time.Duration
+	SolveTime time.Duration
+}
+
+// Run parses input with a fresh solver for day and solves the requested parts (both if none are given).
+func Run(day int, input string, parts ...int) ([]Result, error) {
+	s, err := New(day)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	if err := s.Parse(input); err != nil {
+		return nil, fmt.Errorf("day %d: parsing input: %w", day, err)
+	}
+	parseTime := time.Since(startTime)
+
+	if len(parts) == 0 {
+		parts = []int{1, 2}
+	}
+
+	results := make([]Result, 0, len(parts))
+	for _, part := range parts {
+		var fn func() (int, error)
+		switch part {
+		case 1:
+			fn = s.PartOne
+		case 2:
+			fn = s.PartTwo
+		default:
+			return nil, fmt.Errorf("day %d: invalid part %d", day, part)
+		}
+
+		startTime := time.Now()
+		answer, err := fn()
+		elapsed := time.Since(startTime)
+		if err != nil {
+			return nil, fmt.Errorf("day %d part %d: %w", day, part, err)
+		}
+
+		results = append(results, Result{Day: day, Part: part, Answer: answer, ParseTime: parseTime, SolveTime: elapsed})
+	}
+	return results, nil
+}
+
+// RunMany solves several inputs of the same day concurrently, each with its own solver.
+// The results are returned in the order of the inputs.
+func RunMany(day int, inputs []string, parts ...int) ([][]Result, error) {
+	results := make([][]Result, len(inputs))
+	errs := make([]error, len(inputs))
+
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = Run(day, input, parts...)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("input %d: %w", i+1, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,200 @@
+// Package solvertest runs solvers against example inputs and, if present locally, against the real
+// inputs and their golden answers.
+//
+// A golden answer file (answers/dayNN.txt) holds one "<part>: <answer>" line per known answer:
+//
+//	1: 1227775554
+//	2: 4174379265
+//
+// Empty lines and lines starting with '#' are ignored.
+package solvertest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"advent_of_code/input"
+	"advent_of_code/solver"
+)
+
+// DefaultAnswersDir is the golden answers directory used if AOC_ANSWERS is not set.
+const DefaultAnswersDir = "answers"
+
+// Case is an input with the expected answers of both parts.
+type Case struct {
+	Name    string
+	Input   string
+	PartOne int
+	PartTwo int
+}
+
+// Run solves every case with a fresh solver of the given day and compares both answers.
+// The cases run in parallel, so solvers sharing state between runs are caught by the race detector.
+func Run(t *testing.T, day int, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			results, err := solver.Run(day, c.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range results {
+				want := c.PartOne
+				if r.Part == 2 {
+					want = c.PartTwo
+				}
+				if r.Answer != want {
+					t.Errorf("part %d: got %d, want %d", r.Part, r.Answer, want)
+				}
+			}
+		})
+	}
+}
+
+// ErrorCase is a malformed input with the position its parse error must point to.
+type ErrorCase struct {
+	Name   string
+	Input  string
+	Line   int
+	Column int
+}
+
+// ParseErrors parses every case with a fresh solver of the given day and expects an *input.ParseError
+// at the case's position.
+func ParseErrors(t *testing.T, day int, cases []ErrorCase) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			s, err := solver.New(day)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.Parse(c.Input)
+			var parseErr *input.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected a parse error, got %v", err)
+			}
+			if parseErr.Line != c.Line || parseErr.Column != c.Column {
+				t.Errorf("got error at %d:%d, want %d:%d (%v)", parseErr.Line, parseErr.Column, c.Line, c.Column, err)
+			}
+		})
+	}
+}
+
+// Benchmark runs the parse and part benchmarks of a day on its real input, or on the example if
+// the real input is not available locally.
+func Benchmark(b *testing.B, day int, example string) {
+	puzzleInput := example
+	if data, err := os.ReadFile(input.Path(fromRoot(os.Getenv("AOC_INPUTS"), input.DefaultDir), day)); err == nil {
+		puzzleInput = string(data)
+	}
+
+	for _, bm := range solver.Benchmarks(day, puzzleInput) {
+		b.Run(bm.Name, func(b *testing.B) {
+			op, err := bm.Prepare()
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := op(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Golden solves the real input of a day and compares it to its golden answers.
+// The test is skipped unless both files exist; tests run inside the day's package directory,
+// so relative directories are resolved from the repository root.
+func Golden(t *testing.T, day int) {
+	t.Helper()
+
+	inputDir := fromRoot(os.Getenv("AOC_INPUTS"), input.DefaultDir)
+	answersDir := fromRoot(os.Getenv("AOC_ANSWERS"), DefaultAnswersDir)
+
+	data, err := os.ReadFile(input.Path(inputDir, day))
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no input for day %d in %s", day, inputDir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answers, err := ReadAnswers(input.Path(answersDir, day))
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no golden answers for day %d in %s", day, answersDir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := solver.Run(day, string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		want, ok := answers[r.Part]
+		if !ok {
+			continue
+		}
+		if r.Answer != want {
+			t.Errorf("part %d: got %d, want %d", r.Part, r.Answer, want)
+		}
+	}
+}
+
+// ReadAnswers reads a golden answer file and returns the answers by part.
+func ReadAnswers(path string) (map[int]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	answers := map[int]int{}
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		partStr, answerStr, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected '<part>: <answer>', got '%s'", path, lineNumber, line)
+		}
+		part, err := strconv.Atoi(strings.TrimSpace(partStr))
+		if err != nil || (part != 1 && part != 2) {
+			return nil, fmt.Errorf("%s:%d: invalid part '%s'", path, lineNumber, partStr)
+		}
+		answer, err := strconv.Atoi(strings.TrimSpace(answerStr))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid answer '%s': %w", path, lineNumber, answerStr, err)
+		}
+		answers[part] = answer
+	}
+	return answers, scanner.Err()
+}
+
+func fromRoot(dir, defaultDir string) string {
+	if dir == "" {
+		dir = defaultDir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join("..", dir)
+}
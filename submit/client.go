@@ -0,0 +1,84 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL is the address of the Advent of Code server.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// ErrThrottled is returned if the server refused an answer because the last one was given too recently.
+var ErrThrottled = errors.New("answer given too recently, wait before submitting again")
+
+// Client submits an answer and returns the server's verdict.
+type Client interface {
+	Submit(ctx context.Context, day, part int, answer string) (Verdict, error)
+}
+
+// HTTPClient submits answers to an Advent of Code compatible server.
+type HTTPClient struct {
+	BaseURL string
+	Year    int
+	// Session is the value of the session cookie of a logged in user.
+	Session string
+	HTTP    *http.Client
+}
+
+func (c *HTTPClient) Submit(ctx context.Context, day, part int, answer string) (Verdict, error) {
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.BaseURL, "/"), c.Year, day)
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Unknown, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Unknown, fmt.Errorf("submitting answer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Unknown, fmt.Errorf("submitting answer: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Unknown, fmt.Errorf("reading response: %w", err)
+	}
+	return parseResponse(string(body))
+}
+
+// parseResponse extracts the verdict from the article text of the answer page.
+func parseResponse(body string) (Verdict, error) {
+	switch {
+	case strings.Contains(body, "That's the right answer"):
+		return Correct, nil
+	case strings.Contains(body, "You gave an answer too recently"):
+		return Unknown, ErrThrottled
+	case strings.Contains(body, "You don't seem to be solving the right level"):
+		return Unknown, errors.New("level already solved or not yet unlocked")
+	case strings.Contains(body, "your answer is too high"):
+		return TooHigh, nil
+	case strings.Contains(body, "your answer is too low"):
+		return TooLow, nil
+	case strings.Contains(body, "That's not the right answer"):
+		return Incorrect, nil
+	default:
+		return Unknown, errors.New("unrecognized response from server")
+	}
+}
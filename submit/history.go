@@ -0,0 +1,125 @@
+// Package submit records submitted answers and submits new ones through a Client.
+//
+// The history of submissions is kept in a local JSON file. Answers that are known to be wrong,
+// either because they were rejected before or because they are beyond a known too high/too low
+// bound, are refused without contacting the server.
+package submit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultHistoryPath is the history file used if no other path is given.
+const DefaultHistoryPath = ".aoc/history.json"
+
+// Verdict is the server's response to a submitted answer.
+type Verdict string
+
+const (
+	Unknown   Verdict = ""
+	Correct   Verdict = "correct"
+	Incorrect Verdict = "incorrect"
+	TooHigh   Verdict = "too_high"
+	TooLow    Verdict = "too_low"
+)
+
+// Describe returns a human readable description of what is known about an answer.
+func (v Verdict) Describe() string {
+	switch v {
+	case Correct:
+		return "matches known-correct answer"
+	case Incorrect:
+		return "known wrong answer"
+	case TooHigh:
+		return "known to be too high"
+	case TooLow:
+		return "known to be too low"
+	default:
+		return ""
+	}
+}
+
+// Entry is a single submission.
+type Entry struct {
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  int       `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// History holds all submissions stored in a file.
+type History struct {
+	path    string
+	Entries []Entry `json:"entries"`
+}
+
+// LoadHistory reads the history stored at path; a missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("reading history %s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Save writes the history back to its file.
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("saving history: %w", err)
+	}
+	if err := os.WriteFile(h.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving history: %w", err)
+	}
+	return nil
+}
+
+// Add records a submission.
+func (h *History) Add(e Entry) {
+	h.Entries = append(h.Entries, e)
+}
+
+// Check returns what the history tells about an answer without submitting it. An answer is
+// Incorrect if it was rejected before or differs from the known correct one, and TooHigh/TooLow
+// if it is not below/above an answer that was already too high/too low.
+func (h *History) Check(day, part, answer int) Verdict {
+	verdict := Unknown
+	for _, e := range h.Entries {
+		if e.Day != day || e.Part != part {
+			continue
+		}
+
+		switch {
+		case e.Verdict == Correct:
+			// a known correct answer decides on its own
+			if e.Answer == answer {
+				return Correct
+			}
+			return Incorrect
+		case e.Answer == answer:
+			verdict = e.Verdict
+		case e.Verdict == TooHigh && answer > e.Answer && verdict == Unknown:
+			verdict = TooHigh
+		case e.Verdict == TooLow && answer < e.Answer && verdict == Unknown:
+			verdict = TooLow
+		}
+	}
+	return verdict
+}
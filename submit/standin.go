@@ -0,0 +1,46 @@
+package submit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// StandIn is a local stand-in for the answer endpoint of the Advent of Code server.
+// It checks answers against Answers, keyed by day and part, and replies with the same
+// sentences as the real server.
+type StandIn struct {
+	Year    int
+	Answers map[[2]int]int
+}
+
+func (s *StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var day int
+	if _, err := fmt.Sscanf(r.URL.Path, fmt.Sprintf("/%d/day/%%d/answer", s.Year), &day); err != nil || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+
+	part, err := strconv.Atoi(r.FormValue("level"))
+	if err != nil {
+		http.Error(w, "invalid level", http.StatusBadRequest)
+		return
+	}
+	answer, err := strconv.Atoi(r.FormValue("answer"))
+	if err != nil {
+		fmt.Fprint(w, "<article><p>That's not the right answer.</p></article>")
+		return
+	}
+
+	want, ok := s.Answers[[2]int{day, part}]
+	switch {
+	case !ok:
+		fmt.Fprint(w, "<article><p>You don't seem to be solving the right level.</p></article>")
+	case answer == want:
+		fmt.Fprint(w, "<article><p>That's the right answer! You are one gold star closer.</p></article>")
+	case answer > want:
+		fmt.Fprint(w, "<article><p>That's not the right answer; your answer is too high.</p></article>")
+	default:
+		fmt.Fprint(w, "<article><p>That's not the right answer; your answer is too low.</p></article>")
+	}
+}
@@ -0,0 +1,41 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrKnownWrong is returned if an answer is refused because the history already shows it is wrong.
+var ErrKnownWrong = errors.New("answer is known to be wrong")
+
+// Submitter submits answers that are not known yet and records the verdicts.
+type Submitter struct {
+	History *History
+	Client  Client
+}
+
+// Submit returns the verdict of an answer. Answers already known to be correct are not submitted
+// again, answers known to be wrong are refused with ErrKnownWrong.
+func (s *Submitter) Submit(ctx context.Context, day, part, answer int) (Verdict, error) {
+	switch verdict := s.History.Check(day, part, answer); verdict {
+	case Correct:
+		return verdict, nil
+	case Unknown:
+	default:
+		return verdict, fmt.Errorf("%w: %d is %s", ErrKnownWrong, answer, verdict.Describe())
+	}
+
+	verdict, err := s.Client.Submit(ctx, day, part, strconv.Itoa(answer))
+	if err != nil {
+		return Unknown, err
+	}
+
+	s.History.Add(Entry{Day: day, Part: part, Answer: answer, Verdict: verdict, Time: time.Now()})
+	if err := s.History.Save(); err != nil {
+		return verdict, err
+	}
+	return verdict, nil
+}
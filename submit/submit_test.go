@@ -0,0 +1,78 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSubmitter(t *testing.T) (*Submitter, string) {
+	t.Helper()
+	server := httptest.NewServer(&StandIn{Year: 2025, Answers: map[[2]int]int{{5, 1}: 640, {5, 2}: 1000}})
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Submitter{
+		History: history,
+		Client:  &HTTPClient{BaseURL: server.URL, Year: 2025, Session: "test", HTTP: server.Client()},
+	}, path
+}
+
+func TestSubmit(t *testing.T) {
+	s, path := newTestSubmitter(t)
+	ctx := context.Background()
+
+	steps := []struct {
+		answer  int
+		want    Verdict
+		wantErr error
+	}{
+		{answer: 700, want: TooHigh},
+		{answer: 700, want: TooHigh, wantErr: ErrKnownWrong},
+		{answer: 800, want: TooHigh, wantErr: ErrKnownWrong},
+		{answer: 600, want: TooLow},
+		{answer: 500, want: TooLow, wantErr: ErrKnownWrong},
+		{answer: 640, want: Correct},
+		{answer: 640, want: Correct},
+		{answer: 650, want: Incorrect, wantErr: ErrKnownWrong},
+	}
+	for _, step := range steps {
+		got, err := s.Submit(ctx, 5, 1, step.answer)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("Submit(%d): got error %v, want %v", step.answer, err, step.wantErr)
+		}
+		if got != step.want {
+			t.Errorf("Submit(%d) = %q, want %q", step.answer, got, step.want)
+		}
+	}
+
+	// only the answers that reached the server are recorded, and they survive a reload
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Entries) != 3 {
+		t.Errorf("got %d history entries, want 3", len(history.Entries))
+	}
+	if got := history.Check(5, 1, 640); got != Correct {
+		t.Errorf("Check after reload = %q, want %q", got, Correct)
+	}
+	if got := history.Check(5, 2, 640); got != Unknown {
+		t.Errorf("Check of other part = %q, want %q", got, Unknown)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	if _, err := parseResponse("<article><p>You gave an answer too recently; you have 30s left to wait.</p></article>"); !errors.Is(err, ErrThrottled) {
+		t.Errorf("got %v, want %v", err, ErrThrottled)
+	}
+	if _, err := parseResponse("<html>maintenance</html>"); err == nil {
+		t.Error("expected an error for an unrecognized response")
+	}
+}